@@ -0,0 +1,265 @@
+// Package breaker provides circuit breaker functionality for [http.Client].
+package breaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	defaultWindow      = 10 * time.Second
+	defaultBuckets     = 10
+	defaultRatio       = 0.5
+	defaultMinRequests = 10
+	defaultOpenTimeout = 30 * time.Second
+	defaultProbes      = 1
+)
+
+// ErrOpen returned for requests rejected by open circuit.
+var ErrOpen = errors.New("circuit breaker is open")
+
+type HTTPBreaker struct {
+	next        http.RoundTripper
+	validate    func(res *http.Response, err error) bool
+	key         func(req *http.Request) string
+	onChange    func(key string, from, to State)
+	now         func() time.Time
+	circuits    map[string]*circuit
+	window      time.Duration
+	openTimeout time.Duration
+	ratio       float64
+	minRequests uint
+	probes      uint
+	buckets     int
+	mu          sync.Mutex
+}
+
+// New creates http circuit breaker instance.
+func New(
+	next http.RoundTripper,
+) *HTTPBreaker {
+	return &HTTPBreaker{
+		next:        next,
+		validate:    ValidateDefault,
+		key:         Host,
+		now:         time.Now,
+		circuits:    make(map[string]*circuit),
+		window:      defaultWindow,
+		openTimeout: defaultOpenTimeout,
+		ratio:       defaultRatio,
+		minRequests: defaultMinRequests,
+		probes:      defaultProbes,
+		buckets:     defaultBuckets,
+	}
+}
+
+// RoundTrip [http.RoundTripper] implementation.
+func (b *HTTPBreaker) RoundTrip(req *http.Request) (*http.Response, error) {
+	key := b.key(req)
+	c := b.circuit(key)
+
+	generation, ch, err := b.allow(c)
+	b.notify(key, ch)
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, key)
+	}
+
+	res, err := b.next.RoundTrip(req)
+	if errors.Is(err, context.Canceled) {
+		b.cancel(c, generation)
+
+		return res, err
+	}
+
+	b.notify(key, b.record(c, generation, b.validate(res, err)))
+
+	return res, err
+}
+
+// State returns circuit state for key. Open circuit switches to half-open state on the first request after open
+// timeout. Unknown key has closed circuit.
+func (b *HTTPBreaker) State(key string) State {
+	b.mu.Lock()
+	c, ok := b.circuits[key]
+	b.mu.Unlock()
+
+	if !ok {
+		return Closed
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.state
+}
+
+// Host returns request host with port. It is default circuit key, so every host has own circuit breaker.
+func Host(req *http.Request) string {
+	return req.URL.Host
+}
+
+// ValidateDefault validator counts transport errors and 5xx responses as failures.
+func ValidateDefault(res *http.Response, err error) bool {
+	return err == nil && res != nil && res.StatusCode < http.StatusInternalServerError
+}
+
+// WithRespValidator sets validator for request results. False return counts as failure. Validators of retry package
+// are compatible, so both packages may agree on failures.
+func (b *HTTPBreaker) WithRespValidator(f func(res *http.Response, err error) bool) *HTTPBreaker {
+	b.validate = f
+
+	return b
+}
+
+// WithFailureRatio sets failures ratio in rolling window which opens circuit.
+func (b *HTTPBreaker) WithFailureRatio(ratio float64) *HTTPBreaker {
+	b.ratio = ratio
+
+	return b
+}
+
+// WithMinRequests sets minimal requests count in rolling window required for circuit opening.
+func (b *HTTPBreaker) WithMinRequests(n uint) *HTTPBreaker {
+	b.minRequests = n
+
+	return b
+}
+
+// WithWindow sets rolling window duration and count of buckets it is divided into. Window slides by bucket duration.
+func (b *HTTPBreaker) WithWindow(window time.Duration, buckets int) *HTTPBreaker {
+	b.window = window
+	b.buckets = max(buckets, 1)
+
+	return b
+}
+
+// WithOpenTimeout sets duration of open state before probe requests are allowed.
+func (b *HTTPBreaker) WithOpenTimeout(timeout time.Duration) *HTTPBreaker {
+	b.openTimeout = timeout
+
+	return b
+}
+
+// WithProbes sets count of probe requests in half-open state. Circuit closes when all of them succeed and opens
+// again on any failure.
+func (b *HTTPBreaker) WithProbes(n uint) *HTTPBreaker {
+	b.probes = max(n, 1)
+
+	return b
+}
+
+// WithKey sets function which groups requests by circuits. See [Host].
+func (b *HTTPBreaker) WithKey(f func(req *http.Request) string) *HTTPBreaker {
+	b.key = f
+
+	return b
+}
+
+// WithOnStateChange sets hook called on circuit state transitions.
+func (b *HTTPBreaker) WithOnStateChange(f func(key string, from, to State)) *HTTPBreaker {
+	b.onChange = f
+
+	return b
+}
+
+// circuit returns circuit for key.
+func (b *HTTPBreaker) circuit(key string) *circuit {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c, ok := b.circuits[key]
+	if !ok {
+		c = newCircuit(b.buckets)
+		b.circuits[key] = c
+	}
+
+	return c
+}
+
+// allow checks whether request may be passed through circuit. Returns circuit generation for result recording.
+func (b *HTTPBreaker) allow(c *circuit) (uint64, *change, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var ch *change
+
+	if c.state == Open {
+		now := b.now()
+		if now.Sub(c.openedAt) < b.openTimeout {
+			return 0, nil, ErrOpen
+		}
+
+		ch = c.setState(HalfOpen, now)
+	}
+
+	if c.state == HalfOpen {
+		if c.probes >= b.probes {
+			return 0, ch, ErrOpen
+		}
+
+		c.probes++
+	}
+
+	return c.generation, ch, nil
+}
+
+// record counts request result. Results of requests passed before last state transition are ignored.
+func (b *HTTPBreaker) record(c *circuit, generation uint64, ok bool) *change {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.generation {
+		return nil
+	}
+
+	now := b.now()
+
+	if c.state == HalfOpen {
+		if !ok {
+			return c.setState(Open, now)
+		}
+
+		c.successes++
+		if c.successes < b.probes {
+			return nil
+		}
+
+		return c.setState(Closed, now)
+	}
+
+	c.add(ok, now, max(b.window/time.Duration(b.buckets), 1))
+	if ok {
+		return nil
+	}
+
+	successes, failures := c.totals(now, b.window)
+	total := successes + failures
+
+	if total < b.minRequests || float64(failures) < b.ratio*float64(total) {
+		return nil
+	}
+
+	return c.setState(Open, now)
+}
+
+// cancel frees probe slot of request canceled by client. Canceled requests don't count as successes or failures.
+func (b *HTTPBreaker) cancel(c *circuit, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation == c.generation && c.state == HalfOpen {
+		c.probes--
+	}
+}
+
+// notify calls state change hook.
+func (b *HTTPBreaker) notify(key string, ch *change) {
+	if ch != nil && b.onChange != nil {
+		b.onChange(key, ch.from, ch.to)
+	}
+}
@@ -0,0 +1,336 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	unexpectedError    = "Unexpected error"
+	unexpectedResponse = "Unexpected response"
+	unexpectedChanges  = "Unexpected state changes"
+	firstHost          = "first.localhost"
+	secondHost         = "second.localhost"
+	openTimeout        = time.Minute
+	window             = time.Second
+	count              = 4
+)
+
+type step struct {
+	err           error
+	expectedErr   error
+	host          string
+	advance       time.Duration
+	status        int
+	expectedState State
+}
+
+type roundTripCase struct {
+	name            string
+	steps           []step
+	expectedChanges []string
+	minRequests     uint
+	probes          uint
+}
+
+func (s *suite) TestRoundTrip() {
+	for _, c := range roundTripProvider() {
+		s.Run(c.name, func() {
+			ctrl := gomock.NewController(s.T())
+			next := NewMockRoundTripper(ctrl)
+			now := time.Unix(0, 0)
+
+			var current step
+			var changes []string
+
+			b := New(next).
+				WithWindow(window, count).
+				WithOpenTimeout(openTimeout).
+				WithFailureRatio(0.5).
+				WithMinRequests(c.minRequests).
+				WithProbes(c.probes).
+				WithOnStateChange(func(key string, from, to State) {
+					changes = append(changes, key+": "+from.String()+" -> "+to.String())
+				})
+			b.now = func() time.Time {
+				return now
+			}
+
+			next.EXPECT().
+				RoundTrip(gomock.Any()).
+				DoAndReturn(func(_ *http.Request) (*http.Response, error) {
+					if current.err != nil {
+						return nil, current.err
+					}
+
+					rec := httptest.NewRecorder()
+					rec.WriteHeader(current.status)
+
+					return rec.Result(), nil
+				}).
+				AnyTimes()
+
+			for i, st := range c.steps {
+				current = st
+				now = now.Add(st.advance)
+
+				res, err := b.RoundTrip(request(st.host))
+				if res != nil {
+					_ = res.Body.Close()
+				}
+
+				if st.expectedErr != nil {
+					s.Require().ErrorIs(err, st.expectedErr, unexpectedError, i)
+					s.Nil(res, unexpectedResponse, i)
+				}
+
+				s.Equal(st.expectedState, b.State(st.host), unexpectedState, i)
+			}
+
+			s.Equal(c.expectedChanges, changes, unexpectedChanges)
+		})
+	}
+}
+
+func (s *suite) TestHalfOpenProbes() {
+	ctrl := gomock.NewController(s.T())
+	next := NewMockRoundTripper(ctrl)
+	now := time.Unix(0, 0)
+
+	b := New(next).
+		WithMinRequests(1).
+		WithProbes(0)
+	b.now = func() time.Time {
+		return now
+	}
+
+	next.EXPECT().
+		RoundTrip(gomock.Any()).
+		Return(nil, io.EOF)
+
+	_, err := b.RoundTrip(request(firstHost))
+	s.Require().ErrorIs(err, io.EOF, unexpectedError)
+	s.Equal(Open, b.State(firstHost), unexpectedState)
+
+	now = now.Add(defaultOpenTimeout)
+
+	next.EXPECT().
+		RoundTrip(gomock.Any()).
+		DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			res, rejected := b.RoundTrip(req)
+			s.Require().ErrorIs(rejected, ErrOpen, unexpectedError)
+			s.Nil(res, unexpectedResponse)
+
+			return httptest.NewRecorder().Result(), nil
+		})
+
+	res, err := b.RoundTrip(request(firstHost))
+	s.Require().NoError(err, unexpectedError)
+	s.Equal(http.StatusOK, res.StatusCode, unexpectedResponse)
+	s.Equal(Closed, b.State(firstHost), unexpectedState)
+}
+
+func (s *suite) TestStaleResult() {
+	ctrl := gomock.NewController(s.T())
+	next := NewMockRoundTripper(ctrl)
+
+	var changes []string
+
+	b := New(next).
+		WithMinRequests(1).
+		WithOnStateChange(func(key string, from, to State) {
+			changes = append(changes, key+": "+from.String()+" -> "+to.String())
+		})
+
+	next.EXPECT().
+		RoundTrip(gomock.Any()).
+		DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			next.EXPECT().
+				RoundTrip(gomock.Any()).
+				Return(nil, io.EOF)
+
+			_, err := b.RoundTrip(req)
+			s.Require().ErrorIs(err, io.EOF, unexpectedError)
+
+			return nil, io.EOF
+		})
+
+	_, err := b.RoundTrip(request(firstHost))
+	s.Require().ErrorIs(err, io.EOF, unexpectedError)
+	s.Equal(Open, b.State(firstHost), unexpectedState)
+	s.Equal([]string{firstHost + ": closed -> open"}, changes, unexpectedChanges)
+}
+
+func (s *suite) TestWithKey() {
+	ctrl := gomock.NewController(s.T())
+	next := NewMockRoundTripper(ctrl)
+
+	b := New(next).
+		WithMinRequests(1).
+		WithKey(func(req *http.Request) string {
+			return req.Method + " " + req.URL.Path
+		})
+
+	next.EXPECT().
+		RoundTrip(gomock.Any()).
+		Return(nil, io.EOF)
+
+	_, err := b.RoundTrip(request(firstHost))
+	s.Require().ErrorIs(err, io.EOF, unexpectedError)
+
+	_, err = b.RoundTrip(request(secondHost))
+	s.Require().ErrorIs(err, ErrOpen, unexpectedError)
+	s.Require().ErrorContains(err, "GET /", unexpectedError)
+	s.Equal(Open, b.State("GET /"), unexpectedState)
+	s.Equal(Closed, b.State("POST /"), unexpectedState)
+	s.Len(b.circuits, 1, unexpectedState)
+}
+
+func (s *suite) TestWithRespValidator() {
+	ctrl := gomock.NewController(s.T())
+	next := NewMockRoundTripper(ctrl)
+
+	b := New(next).
+		WithMinRequests(1).
+		WithRespValidator(func(res *http.Response, _ error) bool {
+			return res != nil && res.StatusCode != http.StatusTooManyRequests
+		})
+
+	next.EXPECT().
+		RoundTrip(gomock.Any()).
+		DoAndReturn(func(_ *http.Request) (*http.Response, error) {
+			rec := httptest.NewRecorder()
+			rec.WriteHeader(http.StatusTooManyRequests)
+
+			return rec.Result(), nil
+		})
+
+	res, err := b.RoundTrip(request(firstHost))
+	s.Require().NoError(err, unexpectedError)
+	s.Equal(http.StatusTooManyRequests, res.StatusCode, unexpectedResponse)
+	s.Equal(Open, b.State(firstHost), unexpectedState)
+}
+
+func request(host string) *http.Request {
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "https://"+host+"/", nil)
+
+	return req
+}
+
+func roundTripProvider() []roundTripCase {
+	return []roundTripCase{
+		{
+			name:        "opens on failures ratio",
+			minRequests: count,
+			probes:      1,
+			steps: []step{
+				{host: firstHost, status: http.StatusOK, expectedState: Closed},
+				{host: firstHost, status: http.StatusInternalServerError, expectedState: Closed},
+				{host: firstHost, status: http.StatusOK, expectedState: Closed},
+				{host: firstHost, status: http.StatusBadGateway, expectedState: Open},
+				{host: firstHost, status: http.StatusOK, expectedErr: ErrOpen, expectedState: Open},
+				{host: secondHost, status: http.StatusOK, expectedState: Closed},
+			},
+			expectedChanges: []string{firstHost + ": closed -> open"},
+		},
+		{
+			name:        "closed below min requests",
+			minRequests: count,
+			probes:      1,
+			steps: []step{
+				{host: firstHost, err: io.EOF, expectedErr: io.EOF, expectedState: Closed},
+				{host: firstHost, err: io.EOF, expectedErr: io.EOF, expectedState: Closed},
+				{host: firstHost, err: io.EOF, expectedErr: io.EOF, expectedState: Closed},
+			},
+		},
+		{
+			name:        "closed below failures ratio",
+			minRequests: 2,
+			probes:      1,
+			steps: []step{
+				{host: firstHost, status: http.StatusOK, expectedState: Closed},
+				{host: firstHost, status: http.StatusOK, expectedState: Closed},
+				{host: firstHost, status: http.StatusServiceUnavailable, expectedState: Closed},
+			},
+		},
+		{
+			name:        "expired failures",
+			minRequests: 2,
+			probes:      1,
+			steps: []step{
+				{host: firstHost, status: http.StatusServiceUnavailable, expectedState: Closed},
+				{host: firstHost, advance: window, status: http.StatusServiceUnavailable, expectedState: Closed},
+				{host: firstHost, status: http.StatusOK, expectedState: Closed},
+				{host: firstHost, status: http.StatusOK, expectedState: Closed},
+			},
+		},
+		{
+			name:        "half-open probe success",
+			minRequests: 1,
+			probes:      2,
+			steps: []step{
+				{host: firstHost, err: io.EOF, expectedErr: io.EOF, expectedState: Open},
+				{host: firstHost, advance: openTimeout / 2, expectedErr: ErrOpen, expectedState: Open},
+				{host: firstHost, advance: openTimeout / 2, status: http.StatusOK, expectedState: HalfOpen},
+				{host: firstHost, status: http.StatusOK, expectedState: Closed},
+				{host: firstHost, status: http.StatusOK, expectedState: Closed},
+			},
+			expectedChanges: []string{
+				firstHost + ": closed -> open",
+				firstHost + ": open -> half-open",
+				firstHost + ": half-open -> closed",
+			},
+		},
+		{
+			name:        "half-open probe failure",
+			minRequests: 1,
+			probes:      1,
+			steps: []step{
+				{host: firstHost, status: http.StatusServiceUnavailable, expectedState: Open},
+				{host: firstHost, advance: openTimeout, err: io.EOF, expectedErr: io.EOF, expectedState: Open},
+				{host: firstHost, expectedErr: ErrOpen, expectedState: Open},
+			},
+			expectedChanges: []string{
+				firstHost + ": closed -> open",
+				firstHost + ": open -> half-open",
+				firstHost + ": half-open -> open",
+			},
+		},
+		{
+			name:        "canceled requests",
+			minRequests: 1,
+			probes:      1,
+			steps: []step{
+				{host: firstHost, err: context.Canceled, expectedErr: context.Canceled, expectedState: Closed},
+				{host: firstHost, err: io.EOF, expectedErr: io.EOF, expectedState: Open},
+				{host: firstHost, advance: openTimeout, err: context.Canceled, expectedState: HalfOpen},
+				{host: firstHost, status: http.StatusOK, expectedState: Closed},
+			},
+			expectedChanges: []string{
+				firstHost + ": closed -> open",
+				firstHost + ": open -> half-open",
+				firstHost + ": half-open -> closed",
+			},
+		},
+		{
+			name:        "wrapped transport error",
+			minRequests: 1,
+			probes:      1,
+			steps: []step{
+				{
+					host:          firstHost,
+					err:           errors.Join(errors.New("dial"), io.ErrUnexpectedEOF),
+					expectedErr:   io.ErrUnexpectedEOF,
+					expectedState: Open,
+				},
+			},
+			expectedChanges: []string{firstHost + ": closed -> open"},
+		},
+	}
+}
@@ -0,0 +1,93 @@
+package breaker
+
+import (
+	"sync"
+	"time"
+)
+
+// bucket holds request results of rolling window part.
+type bucket struct {
+	start     time.Time
+	successes uint
+	failures  uint
+}
+
+// circuit holds breaker state of single host.
+type circuit struct {
+	openedAt   time.Time
+	buckets    []bucket
+	generation uint64
+	probes     uint
+	successes  uint
+	state      State
+	mu         sync.Mutex
+}
+
+// change describes circuit state transition.
+type change struct {
+	from State
+	to   State
+}
+
+// newCircuit creates closed circuit with rolling window of given buckets count.
+func newCircuit(buckets int) *circuit {
+	return &circuit{
+		buckets: make([]bucket, buckets),
+	}
+}
+
+// setState switches circuit to new state and resets its counters.
+func (c *circuit) setState(s State, now time.Time) *change {
+	ch := &change{from: c.state, to: s}
+
+	c.state = s
+	c.openedAt = now
+	c.generation++
+	c.probes = 0
+	c.successes = 0
+	clear(c.buckets)
+
+	return ch
+}
+
+// add counts request result in rolling window.
+func (c *circuit) add(ok bool, now time.Time, size time.Duration) {
+	start := now.Truncate(size)
+	oldest := &c.buckets[0]
+
+	for i := range c.buckets {
+		if c.buckets[i].start.Equal(start) {
+			oldest = &c.buckets[i]
+
+			break
+		}
+
+		if c.buckets[i].start.Before(oldest.start) {
+			oldest = &c.buckets[i]
+		}
+	}
+
+	if !oldest.start.Equal(start) {
+		*oldest = bucket{start: start}
+	}
+
+	if ok {
+		oldest.successes++
+	} else {
+		oldest.failures++
+	}
+}
+
+// totals returns successes and failures count in rolling window.
+func (c *circuit) totals(now time.Time, window time.Duration) (uint, uint) {
+	var successes, failures uint
+
+	for _, b := range c.buckets {
+		if now.Sub(b.start) < window {
+			successes += b.successes
+			failures += b.failures
+		}
+	}
+
+	return successes, failures
+}
@@ -0,0 +1,67 @@
+package breaker
+
+import (
+	"time"
+)
+
+const unexpectedTotals = "Unexpected window totals"
+
+type circuitCase struct {
+	name              string
+	results           []bool
+	step              time.Duration
+	expectedSuccesses uint
+	expectedFailures  uint
+}
+
+func (s *suite) TestCircuitWindow() {
+	for _, c := range circuitProvider() {
+		s.Run(c.name, func() {
+			const size = 100 * time.Millisecond
+
+			circ := newCircuit(count)
+			now := time.Unix(0, 0)
+
+			for _, ok := range c.results {
+				circ.add(ok, now, size)
+				now = now.Add(c.step)
+			}
+
+			now = now.Add(-c.step)
+			successes, failures := circ.totals(now, count*size)
+
+			s.Equal(c.expectedSuccesses, successes, unexpectedTotals)
+			s.Equal(c.expectedFailures, failures, unexpectedTotals)
+		})
+	}
+}
+
+func circuitProvider() []circuitCase {
+	return []circuitCase{
+		{
+			name:              "same bucket",
+			results:           []bool{true, false, false},
+			expectedSuccesses: 1,
+			expectedFailures:  2,
+		},
+		{
+			name:              "buckets within window",
+			results:           []bool{true, false, true, false},
+			step:              100 * time.Millisecond,
+			expectedSuccesses: 2,
+			expectedFailures:  2,
+		},
+		{
+			name:              "oldest buckets reused",
+			results:           []bool{false, false, true, true, true, true},
+			step:              100 * time.Millisecond,
+			expectedSuccesses: 4,
+		},
+		{
+			name:              "expired buckets",
+			results:           []bool{false, false, true},
+			step:              time.Second,
+			expectedSuccesses: 1,
+		},
+	}
+}
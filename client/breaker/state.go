@@ -0,0 +1,24 @@
+package breaker
+
+// State is a circuit breaker state.
+type State int
+
+const (
+	Closed   State = iota // Requests are passed, failures are counted.
+	Open                  // Requests are rejected with ErrOpen.
+	HalfOpen              // Limited count of probe requests is passed.
+)
+
+// String returns state name.
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
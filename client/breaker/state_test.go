@@ -0,0 +1,42 @@
+package breaker
+
+const unexpectedState = "Unexpected state"
+
+type stateCase struct {
+	name     string
+	expected string
+	state    State
+}
+
+func (s *suite) TestStateString() {
+	for _, c := range stateProvider() {
+		s.Run(c.name, func() {
+			s.Equal(c.expected, c.state.String(), unexpectedState)
+		})
+	}
+}
+
+func stateProvider() []stateCase {
+	return []stateCase{
+		{
+			name:     "closed",
+			state:    Closed,
+			expected: "closed",
+		},
+		{
+			name:     "open",
+			state:    Open,
+			expected: "open",
+		},
+		{
+			name:     "half-open",
+			state:    HalfOpen,
+			expected: "half-open",
+		},
+		{
+			name:     "unknown",
+			state:    State(-1),
+			expected: "unknown",
+		},
+	}
+}
@@ -0,0 +1,98 @@
+package concurrency
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	minGradient   = 0.5
+	gradientReset = 1000
+	halfLimit     = 2
+)
+
+// Sample describes result of request for limit adaptation.
+type Sample struct {
+	RTT      time.Duration // Time to response headers.
+	InFlight int           // In-flight requests count when response received.
+	Dropped  bool          // Response or error indicates overload, e.g. 503 status or timeout.
+}
+
+// Algorithm adapts concurrency limit by request results. Every host has own algorithm instance, so implementation
+// isn't required to be safe for concurrent use.
+type Algorithm interface {
+	Update(limit float64, s Sample) float64
+}
+
+// AIMD is additive increase, multiplicative decrease algorithm. Limit grows by one while at least half of it is used
+// and shrinks by backoff ratio on drops.
+type AIMD struct {
+	backoff float64
+	timeout time.Duration
+}
+
+// NewAIMD creates AIMD algorithm instance. Backoff is a ratio of limit decrease, e.g. 0.9. Requests slower than
+// timeout are treated as dropped. Zero timeout disables latency check.
+func NewAIMD(backoff float64, timeout time.Duration) *AIMD {
+	return &AIMD{
+		backoff: backoff,
+		timeout: timeout,
+	}
+}
+
+// Update [Algorithm] implementation.
+func (a *AIMD) Update(limit float64, s Sample) float64 {
+	if s.Dropped || (a.timeout > 0 && s.RTT > a.timeout) {
+		return limit * a.backoff
+	}
+
+	if float64(s.InFlight*halfLimit) >= limit {
+		return limit + 1
+	}
+
+	return limit
+}
+
+// Gradient algorithm adapts limit by ratio of minimal observed latency to current one. Limit grows while latency
+// stays close to minimal and shrinks when upstream queues requests. Minimal latency is re-measured periodically.
+type Gradient struct {
+	smoothing float64
+	minRTT    time.Duration
+	samples   int
+}
+
+// NewGradient creates gradient algorithm instance. Smoothing is a weight of new limit value, e.g. 0.2.
+func NewGradient(smoothing float64) *Gradient {
+	return &Gradient{
+		smoothing: smoothing,
+	}
+}
+
+// Update [Algorithm] implementation.
+func (g *Gradient) Update(limit float64, s Sample) float64 {
+	if s.RTT <= 0 {
+		return limit
+	}
+
+	g.samples++
+	if g.samples > gradientReset {
+		g.samples = 0
+		g.minRTT = 0
+	}
+
+	if g.minRTT == 0 || s.RTT < g.minRTT {
+		g.minRTT = s.RTT
+	}
+
+	gradient := max(minGradient, min(1, float64(g.minRTT)/float64(s.RTT)))
+	if s.Dropped {
+		gradient = minGradient
+	}
+
+	target := limit*gradient + math.Sqrt(limit)
+	if float64(s.InFlight*halfLimit) < limit {
+		target = min(target, limit)
+	}
+
+	return limit*(1-g.smoothing) + target*g.smoothing
+}
@@ -0,0 +1,119 @@
+package concurrency
+
+import (
+	"time"
+)
+
+type algorithmCase struct {
+	algorithm Algorithm
+	name      string
+	samples   []Sample
+	limit     float64
+	expected  float64
+}
+
+func (s *suite) TestAlgorithm() {
+	for _, c := range algorithmProvider() {
+		s.Run(c.name, func() {
+			limit := c.limit
+
+			for _, sample := range c.samples {
+				limit = c.algorithm.Update(limit, sample)
+			}
+
+			s.InDelta(c.expected, limit, 1e-9, unexpectedLimit)
+		})
+	}
+}
+
+func (s *suite) TestGradientReset() {
+	g := NewGradient(1)
+
+	for range gradientReset - 1 {
+		g.Update(defaultLimit, Sample{RTT: time.Millisecond})
+	}
+
+	g.Update(defaultLimit, Sample{RTT: time.Second})
+	s.Equal(time.Millisecond, g.minRTT, unexpectedLimit)
+
+	g.Update(defaultLimit, Sample{RTT: time.Second})
+	s.Equal(time.Second, g.minRTT, unexpectedLimit)
+}
+
+func algorithmProvider() []algorithmCase {
+	return []algorithmCase{
+		{
+			name:      "aimd increase",
+			algorithm: NewAIMD(defaultBackoff, time.Second),
+			limit:     10,
+			samples:   []Sample{{RTT: time.Millisecond, InFlight: 5}, {RTT: time.Millisecond, InFlight: 6}},
+			expected:  12,
+		},
+		{
+			name:      "aimd underused",
+			algorithm: NewAIMD(defaultBackoff, time.Second),
+			limit:     10,
+			samples:   []Sample{{RTT: time.Millisecond, InFlight: 4}},
+			expected:  10,
+		},
+		{
+			name:      "aimd drop",
+			algorithm: NewAIMD(0.5, 0),
+			limit:     10,
+			samples:   []Sample{{RTT: time.Hour, InFlight: 10, Dropped: true}},
+			expected:  5,
+		},
+		{
+			name:      "aimd timeout",
+			algorithm: NewAIMD(0.5, time.Second),
+			limit:     10,
+			samples:   []Sample{{RTT: 2 * time.Second, InFlight: 10}},
+			expected:  5,
+		},
+		{
+			name:      "gradient without latency",
+			algorithm: NewGradient(1),
+			limit:     16,
+			samples:   []Sample{{InFlight: 16}},
+			expected:  16,
+		},
+		{
+			name:      "gradient stable latency",
+			algorithm: NewGradient(1),
+			limit:     16,
+			samples:   []Sample{{RTT: time.Millisecond, InFlight: 16}},
+			expected:  20,
+		},
+		{
+			name:      "gradient growing latency",
+			algorithm: NewGradient(1),
+			limit:     16,
+			samples: []Sample{
+				{RTT: time.Millisecond, InFlight: 1},
+				{RTT: 2 * time.Millisecond, InFlight: 16},
+			},
+			expected: 12,
+		},
+		{
+			name:      "gradient smoothing",
+			algorithm: NewGradient(0.5),
+			limit:     16,
+			samples:   []Sample{{RTT: time.Millisecond, InFlight: 16}},
+			expected:  18,
+		},
+		{
+			name:      "gradient drop",
+			algorithm: NewGradient(1),
+			limit:     16,
+			samples:   []Sample{{RTT: time.Millisecond, InFlight: 16, Dropped: true}},
+			expected:  12,
+		},
+		{
+			name:      "gradient underused",
+			algorithm: NewGradient(1),
+			limit:     16,
+			samples:   []Sample{{RTT: time.Millisecond, InFlight: 4}},
+			expected:  16,
+		},
+	}
+}
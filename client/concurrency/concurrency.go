@@ -0,0 +1,168 @@
+// Package concurrency provides adaptive concurrency limiting for [http.Client].
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	defaultLimit    = 20
+	defaultMinLimit = 1
+	defaultMaxLimit = 200
+	defaultBackoff  = 0.9
+)
+
+type HTTPLimiter struct {
+	next      http.RoundTripper
+	validate  func(res *http.Response, err error) bool
+	algorithm func() Algorithm
+	key       func(req *http.Request) string
+	gates     map[string]*gate
+	limit     float64
+	minLimit  float64
+	maxLimit  float64
+	mu        sync.Mutex
+}
+
+// New creates http concurrency limiter instance with AIMD algorithm.
+func New(
+	next http.RoundTripper,
+) *HTTPLimiter {
+	return &HTTPLimiter{
+		next:     next,
+		validate: ValidateDefault,
+		algorithm: func() Algorithm {
+			return NewAIMD(defaultBackoff, 0)
+		},
+		key:      Host,
+		gates:    make(map[string]*gate),
+		limit:    defaultLimit,
+		minLimit: defaultMinLimit,
+		maxLimit: defaultMaxLimit,
+	}
+}
+
+// RoundTrip [http.RoundTripper] implementation.
+func (l *HTTPLimiter) RoundTrip(req *http.Request) (*http.Response, error) {
+	g := l.gate(l.key(req))
+
+	if err := g.acquire(req.Context()); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	res, err := l.next.RoundTrip(req)
+
+	_, inflight := g.current()
+	s := &Sample{
+		RTT:      time.Since(start),
+		InFlight: inflight,
+		Dropped:  !l.validate(res, err),
+	}
+
+	if errors.Is(err, context.Canceled) {
+		s = nil
+	}
+
+	release := func() {
+		g.release(s, l.minLimit, l.maxLimit)
+	}
+
+	// Body of upgraded connection is io.ReadWriteCloser, so it can't be wrapped. Connection may live long,
+	// so it doesn't hold the gate.
+	if res == nil || res.Body == nil || res.StatusCode == http.StatusSwitchingProtocols {
+		release()
+
+		return res, err
+	}
+
+	res.Body = &releaseBody{
+		ReadCloser: res.Body,
+		release:    sync.OnceFunc(release),
+	}
+
+	return res, err
+}
+
+// Limit returns current concurrency limit and in-flight requests count for key. Unknown key has initial limit.
+func (l *HTTPLimiter) Limit(key string) (int, int) {
+	l.mu.Lock()
+	g, ok := l.gates[key]
+	l.mu.Unlock()
+
+	if !ok {
+		return int(l.limit), 0
+	}
+
+	return g.current()
+}
+
+// Host returns request host with port. It is default limit key, so every host has own limit.
+func Host(req *http.Request) string {
+	return req.URL.Host
+}
+
+// ValidateDefault validator treats 503 responses and timeouts as dropped requests.
+func ValidateDefault(res *http.Response, err error) bool {
+	var netErr net.Error
+
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return false
+	}
+
+	return res == nil || res.StatusCode != http.StatusServiceUnavailable
+}
+
+// WithLimits sets initial, minimal and maximal concurrency limits.
+func (l *HTTPLimiter) WithLimits(initial, minLimit, maxLimit int) *HTTPLimiter {
+	l.minLimit = float64(max(minLimit, 1))
+	l.maxLimit = float64(max(maxLimit, minLimit, 1))
+	l.limit = min(max(float64(initial), l.minLimit), l.maxLimit)
+
+	return l
+}
+
+// WithAlgorithm sets factory of limit adaptation algorithm. Every host gets own algorithm instance:
+//
+//	l.WithAlgorithm(func() concurrency.Algorithm {
+//		return concurrency.NewGradient(0.2)
+//	})
+func (l *HTTPLimiter) WithAlgorithm(f func() Algorithm) *HTTPLimiter {
+	l.algorithm = f
+
+	return l
+}
+
+// WithRespValidator sets validator for request results. False return means dropped request which indicates
+// upstream overload. Validators of retry package are compatible.
+func (l *HTTPLimiter) WithRespValidator(f func(res *http.Response, err error) bool) *HTTPLimiter {
+	l.validate = f
+
+	return l
+}
+
+// WithKey sets function which groups requests by limits. See [Host].
+func (l *HTTPLimiter) WithKey(f func(req *http.Request) string) *HTTPLimiter {
+	l.key = f
+
+	return l
+}
+
+// gate returns gate for key.
+func (l *HTTPLimiter) gate(key string) *gate {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	g, ok := l.gates[key]
+	if !ok {
+		g = newGate(l.algorithm(), l.limit)
+		l.gates[key] = g
+	}
+
+	return g
+}
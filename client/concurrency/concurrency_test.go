@@ -0,0 +1,260 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	unexpectedResponse = "Unexpected response"
+	unexpectedValid    = "Unexpected validation result"
+	URL                = "https://localhost"
+)
+
+type adaptCase struct {
+	err           error
+	name          string
+	status        int
+	expectedLimit int
+}
+
+type validateCase struct {
+	res      *http.Response
+	err      error
+	name     string
+	expected bool
+}
+
+// timeoutError is a network timeout error.
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+// upgradedConn is a body of 101 Switching Protocols response.
+type upgradedConn struct {
+	io.Reader
+	io.Writer
+}
+
+func (*upgradedConn) Close() error { return nil }
+
+func (s *suite) TestRoundTripQueue() {
+	ctrl := gomock.NewController(s.T())
+	next := NewMockRoundTripper(ctrl)
+
+	l := New(next).WithLimits(1, 1, 1)
+
+	next.EXPECT().
+		RoundTrip(gomock.Any()).
+		DoAndReturn(func(_ *http.Request) (*http.Response, error) {
+			return httptest.NewRecorder().Result(), nil
+		}).
+		Times(2)
+
+	first, err := l.RoundTrip(request(context.Background()))
+	s.Require().NoError(err, unexpectedError)
+
+	limit, inflight := l.Limit("localhost")
+	s.Equal(1, limit, unexpectedLimit)
+	s.Equal(1, inflight, unexpectedInFlight)
+
+	done := make(chan *http.Response)
+
+	go func() {
+		res, e := l.RoundTrip(request(context.Background()))
+		s.NoError(e, unexpectedError)
+		done <- res
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+
+	_, err = l.RoundTrip(request(ctx))
+	s.Require().ErrorIs(err, context.DeadlineExceeded, unexpectedError)
+
+	s.Require().NoError(first.Body.Close(), unexpectedError)
+	s.Require().NoError(first.Body.Close(), unexpectedError)
+
+	second := <-done
+	s.Require().NoError(second.Body.Close(), unexpectedError)
+
+	_, inflight = l.Limit("localhost")
+	s.Equal(0, inflight, unexpectedInFlight)
+}
+
+func (s *suite) TestRoundTripUpgrade() {
+	ctrl := gomock.NewController(s.T())
+	next := NewMockRoundTripper(ctrl)
+	conn := &upgradedConn{}
+
+	l := New(next)
+
+	next.EXPECT().
+		RoundTrip(gomock.Any()).
+		Return(&http.Response{StatusCode: http.StatusSwitchingProtocols, Body: conn}, nil)
+
+	res, err := l.RoundTrip(request(context.Background()))
+	s.Require().NoError(err, unexpectedError)
+
+	rwc, ok := res.Body.(io.ReadWriteCloser)
+	s.Require().True(ok, unexpectedResponse)
+	s.Same(conn, rwc, unexpectedResponse)
+
+	_, inflight := l.Limit("localhost")
+	s.Equal(0, inflight, unexpectedInFlight)
+}
+
+func (s *suite) TestRoundTripAdapt() {
+	for _, c := range adaptProvider() {
+		s.Run(c.name, func() {
+			ctrl := gomock.NewController(s.T())
+			next := NewMockRoundTripper(ctrl)
+
+			l := New(next).
+				WithLimits(10, 1, 100).
+				WithAlgorithm(func() Algorithm {
+					return NewAIMD(0.5, 0)
+				})
+
+			next.EXPECT().
+				RoundTrip(gomock.Any()).
+				DoAndReturn(func(_ *http.Request) (*http.Response, error) {
+					if c.err != nil {
+						return nil, c.err
+					}
+
+					rec := httptest.NewRecorder()
+					rec.WriteHeader(c.status)
+
+					return rec.Result(), nil
+				})
+
+			res, err := l.RoundTrip(request(context.Background()))
+			if c.err != nil {
+				s.Require().ErrorIs(err, c.err, unexpectedError)
+			} else {
+				s.Require().NoError(err, unexpectedError)
+				s.Equal(c.status, res.StatusCode, unexpectedResponse)
+				s.Require().NoError(res.Body.Close(), unexpectedError)
+			}
+
+			limit, inflight := l.Limit("localhost")
+			s.Equal(c.expectedLimit, limit, unexpectedLimit)
+			s.Equal(0, inflight, unexpectedInFlight)
+		})
+	}
+}
+
+func (s *suite) TestRoundTripOptions() {
+	ctrl := gomock.NewController(s.T())
+	next := NewMockRoundTripper(ctrl)
+
+	l := New(next).
+		WithLimits(2, 0, 0).
+		WithKey(func(req *http.Request) string {
+			return req.Method
+		}).
+		WithRespValidator(func(res *http.Response, _ error) bool {
+			return res.StatusCode != http.StatusTooManyRequests
+		})
+
+	next.EXPECT().
+		RoundTrip(gomock.Any()).
+		DoAndReturn(func(_ *http.Request) (*http.Response, error) {
+			rec := httptest.NewRecorder()
+			rec.WriteHeader(http.StatusTooManyRequests)
+
+			return rec.Result(), nil
+		})
+
+	res, err := l.RoundTrip(request(context.Background()))
+	s.Require().NoError(err, unexpectedError)
+	s.Require().NoError(res.Body.Close(), unexpectedError)
+
+	limit, _ := l.Limit(http.MethodGet)
+	s.Equal(1, limit, unexpectedLimit)
+
+	limit, inflight := l.Limit(http.MethodPost)
+	s.Equal(1, limit, unexpectedLimit)
+	s.Equal(0, inflight, unexpectedInFlight)
+	s.Len(l.gates, 1, unexpectedLimit)
+}
+
+func (s *suite) TestValidateDefault() {
+	for _, c := range validateProvider() {
+		s.Run(c.name, func() {
+			s.Equal(c.expected, ValidateDefault(c.res, c.err), unexpectedValid)
+		})
+	}
+}
+
+func request(ctx context.Context) *http.Request {
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, URL, nil)
+
+	return req
+}
+
+func adaptProvider() []adaptCase {
+	return []adaptCase{
+		{
+			name:          "success",
+			status:        http.StatusOK,
+			expectedLimit: 10,
+		},
+		{
+			name:          "overload",
+			status:        http.StatusServiceUnavailable,
+			expectedLimit: 5,
+		},
+		{
+			name:          "timeout",
+			err:           context.DeadlineExceeded,
+			expectedLimit: 5,
+		},
+		{
+			name:          "canceled",
+			err:           errors.Join(io.EOF, context.Canceled),
+			expectedLimit: 10,
+		},
+	}
+}
+
+func validateProvider() []validateCase {
+	return []validateCase{
+		{
+			name:     "success",
+			res:      &http.Response{StatusCode: http.StatusOK},
+			expected: true,
+		},
+		{
+			name:     "server error",
+			res:      &http.Response{StatusCode: http.StatusInternalServerError},
+			expected: true,
+		},
+		{
+			name: "unavailable",
+			res:  &http.Response{StatusCode: http.StatusServiceUnavailable},
+		},
+		{
+			name: "deadline",
+			err:  context.DeadlineExceeded,
+		},
+		{
+			name: "network timeout",
+			err:  timeoutError{},
+		},
+		{
+			name:     "network error",
+			err:      io.EOF,
+			expected: true,
+		},
+	}
+}
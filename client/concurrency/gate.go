@@ -0,0 +1,96 @@
+package concurrency
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// gate limits in-flight requests of single host. Excess requests wait in FIFO queue.
+type gate struct {
+	algorithm Algorithm
+	waiters   []chan struct{}
+	limit     float64
+	inflight  int
+	mu        sync.Mutex
+}
+
+// newGate creates gate with initial limit.
+func newGate(algorithm Algorithm, limit float64) *gate {
+	return &gate{
+		algorithm: algorithm,
+		limit:     limit,
+	}
+}
+
+// acquire takes in-flight slot. It waits in queue until slot is free or context is done.
+func (g *gate) acquire(ctx context.Context) error {
+	g.mu.Lock()
+
+	if len(g.waiters) == 0 && g.inflight < int(g.limit) {
+		g.inflight++
+		g.mu.Unlock()
+
+		return nil
+	}
+
+	ch := make(chan struct{})
+	g.waiters = append(g.waiters, ch)
+	g.mu.Unlock()
+
+	select {
+	case <-ch:
+		return nil
+	case <-ctx.Done():
+		g.abandon(ch)
+
+		return ctx.Err()
+	}
+}
+
+// abandon removes waiter from queue. Slot is passed to next waiter when it was already granted.
+func (g *gate) abandon(ch chan struct{}) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	idx := slices.Index(g.waiters, ch)
+	if idx >= 0 {
+		g.waiters = slices.Delete(g.waiters, idx, idx+1)
+
+		return
+	}
+
+	g.inflight--
+	g.grant()
+}
+
+// release frees in-flight slot. Limit is adapted by sample when it is not nil.
+func (g *gate) release(s *Sample, minLimit, maxLimit float64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.inflight--
+
+	if s != nil {
+		g.limit = min(max(g.algorithm.Update(g.limit, *s), minLimit), maxLimit)
+	}
+
+	g.grant()
+}
+
+// current returns limit and in-flight requests count.
+func (g *gate) current() (int, int) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return int(g.limit), g.inflight
+}
+
+// grant passes free slots to waiting requests.
+func (g *gate) grant() {
+	for len(g.waiters) > 0 && g.inflight < int(g.limit) {
+		g.inflight++
+		close(g.waiters[0])
+		g.waiters = g.waiters[1:]
+	}
+}
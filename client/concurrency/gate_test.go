@@ -0,0 +1,96 @@
+package concurrency
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	unexpectedError    = "Unexpected error"
+	unexpectedInFlight = "Unexpected in-flight requests count"
+	unexpectedLimit    = "Unexpected limit"
+)
+
+func (s *suite) TestGateQueue() {
+	g := newGate(NewAIMD(defaultBackoff, 0), 1)
+
+	s.Require().NoError(g.acquire(context.Background()), unexpectedError)
+
+	order := make(chan int, 2)
+
+	for i := range 2 {
+		go func() {
+			s.NoError(g.acquire(context.Background()), unexpectedError)
+			order <- i
+		}()
+
+		s.Eventually(func() bool {
+			g.mu.Lock()
+			defer g.mu.Unlock()
+
+			return len(g.waiters) == i+1
+		}, time.Second, time.Millisecond, unexpectedInFlight)
+	}
+
+	g.release(nil, 1, 1)
+	s.Equal(0, <-order, unexpectedInFlight)
+
+	g.release(nil, 1, 1)
+	s.Equal(1, <-order, unexpectedInFlight)
+
+	limit, inflight := g.current()
+	s.Equal(1, limit, unexpectedLimit)
+	s.Equal(1, inflight, unexpectedInFlight)
+}
+
+func (s *suite) TestGateCancel() {
+	g := newGate(NewAIMD(defaultBackoff, 0), 1)
+
+	s.Require().NoError(g.acquire(context.Background()), unexpectedError)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	s.Require().ErrorIs(g.acquire(ctx), context.Canceled, unexpectedError)
+	s.Empty(g.waiters, unexpectedInFlight)
+
+	_, inflight := g.current()
+	s.Equal(1, inflight, unexpectedInFlight)
+}
+
+func (s *suite) TestGateAbandonGranted() {
+	g := newGate(NewAIMD(defaultBackoff, 0), 1)
+
+	s.Require().NoError(g.acquire(context.Background()), unexpectedError)
+
+	granted := make(chan struct{})
+	waiter := make(chan struct{})
+	g.waiters = append(g.waiters, granted, waiter)
+
+	g.release(nil, 1, 1)
+	g.abandon(granted)
+
+	_, inflight := g.current()
+	s.Equal(1, inflight, unexpectedInFlight)
+	s.Empty(g.waiters, unexpectedInFlight)
+
+	<-waiter
+}
+
+func (s *suite) TestGateAdapt() {
+	g := newGate(NewAIMD(0.5, 0), 4)
+
+	s.Require().NoError(g.acquire(context.Background()), unexpectedError)
+	g.release(&Sample{Dropped: true}, 3, 10)
+
+	limit, _ := g.current()
+	s.Equal(3, limit, unexpectedLimit)
+
+	for range 10 {
+		s.Require().NoError(g.acquire(context.Background()), unexpectedError)
+		g.release(&Sample{InFlight: 10}, 3, 5)
+	}
+
+	limit, _ = g.current()
+	s.Equal(5, limit, unexpectedLimit)
+}
@@ -0,0 +1,20 @@
+package concurrency
+
+import (
+	"io"
+)
+
+// releaseBody calls release function after response body closing.
+type releaseBody struct {
+	io.ReadCloser
+
+	release func()
+}
+
+// Close [io.Closer] implementation.
+func (b *releaseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+
+	return err
+}
@@ -0,0 +1,16 @@
+package concurrency
+
+import (
+	"testing"
+
+	ss "github.com/stretchr/testify/suite"
+)
+
+type suite struct {
+	ss.Suite
+}
+
+// TestRun run tests suite.
+func TestRun(t *testing.T) {
+	ss.Run(t, &suite{})
+}
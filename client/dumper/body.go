@@ -0,0 +1,68 @@
+package dumper
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+const (
+	truncatedTemplate = "\n... [truncated, original length: %d bytes]"
+	truncatedUnknown  = "\n... [truncated, original length: unknown]"
+)
+
+// replayBody returns already read head of body before the rest of original body.
+type replayBody struct {
+	io.Reader
+	io.Closer
+}
+
+// truncation describes body cut off in dump by size limit.
+type truncation struct {
+	length    int64 // original length of body, -1 if unknown
+	truncated bool
+}
+
+// bodySize returns original length of dumped body.
+func (t truncation) bodySize(body []byte) int64 {
+	if t.truncated {
+		return t.length
+	}
+
+	return int64(len(body))
+}
+
+// appendHead appends to dump no more than limit bytes of body. Body is replaced by reader that streams
+// complete content.
+func appendHead(dump []byte, body *io.ReadCloser, length, limit int64) ([]byte, truncation, error) {
+	if *body == nil || *body == http.NoBody {
+		return dump, truncation{}, nil
+	}
+
+	orig := *body
+	head, e := io.ReadAll(io.LimitReader(orig, limit+1))
+	*body = &replayBody{Reader: io.MultiReader(bytes.NewReader(head), orig), Closer: orig}
+
+	if e != nil {
+		return nil, truncation{}, e
+	}
+
+	if int64(len(head)) <= limit {
+		return append(dump, head...), truncation{}, nil
+	}
+
+	return append(dump, head[:limit]...), truncation{length: length, truncated: true}, nil
+}
+
+// truncationMarker returns text appended to truncated body in text dumps.
+func truncationMarker(truncated bool, length int64) string {
+	switch {
+	case !truncated:
+		return ""
+	case length < 0:
+		return truncatedUnknown
+	default:
+		return fmt.Sprintf(truncatedTemplate, length)
+	}
+}
@@ -0,0 +1,224 @@
+package dumper
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing/iotest"
+
+	"bou.ke/monkey"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/nafigator/http/headers"
+	"github.com/nafigator/http/mime"
+)
+
+const (
+	longBody = `{"name":"Boris","age":20,"city":"Moscow"}`
+)
+
+type bodyLimitCase struct {
+	request          func() *http.Request
+	response         func() *http.Response
+	name             string
+	expectedRequest  string
+	expectedResponse string
+	expectedLog      string
+	expectedSent     string
+	expectedReceived string
+	expectedSizes    [2]int64
+	usePatch         patch
+}
+
+func (s *suite) TestBodyLimit() {
+	for _, c := range bodyLimitProvider() {
+		s.Run(c.name, func() {
+			ctrl := gomock.NewController(s.T())
+			next := NewMockRoundTripper(ctrl)
+			rec := &recorder{}
+			ob, logs := observer.New(zap.ErrorLevel)
+
+			d := New(next, nil).
+				WithRecordFlusher(rec).
+				WithErrLogger(zap.New(ob).Sugar()).
+				WithBodyLimit(10)
+
+			req := c.request()
+			sent := ""
+
+			next.EXPECT().
+				RoundTrip(req).
+				DoAndReturn(func(r *http.Request) (*http.Response, error) {
+					if r.Body != nil {
+						b, _ := io.ReadAll(r.Body)
+						sent = string(b)
+					}
+
+					return c.response(), nil
+				}).
+				Times(1)
+
+			if c.usePatch > 0 {
+				applyPatch(c.usePatch)
+				defer func() {
+					monkey.UnpatchAll()
+				}()
+			}
+
+			res, err := d.RoundTrip(req)
+			s.Require().NoError(err, unexpectedError)
+
+			received, _ := io.ReadAll(res.Body)
+
+			s.Require().Len(rec.records, 1, unexpectedMsgCount)
+			r := rec.records[0]
+			text := Text("%s\n\n%s")(r)
+
+			s.Contains(text, c.expectedRequest+"\n\n", unexpectedResults)
+			s.True(strings.HasSuffix(text, c.expectedResponse), unexpectedResults)
+			s.NotContains(string(r.RequestBody)+string(r.ResponseBody), "truncated", unexpectedBody)
+			s.Equal(c.expectedSizes, [2]int64{r.RequestBodySize, r.ResponseBodySize}, unexpectedBody)
+			s.Equal(c.expectedSent, sent, unexpectedBody)
+			s.Equal(c.expectedReceived, string(received), unexpectedBody)
+
+			if c.expectedLog != "" {
+				s.Require().Equal(1, logs.Len(), unexpectedMsgCount)
+				s.Equal(c.expectedLog, logs.All()[0].Message, unexpectedMessage)
+			}
+		})
+	}
+}
+
+func bodyLimitProvider() []bodyLimitCase {
+	post := func() *http.Request {
+		req, _ := http.NewRequest(http.MethodPost, URL, strings.NewReader(longBody))
+		req.Header.Set(headers.ContentType, mime.JSON)
+
+		return req
+	}
+
+	respond := func() *http.Response {
+		return &http.Response{
+			StatusCode:    http.StatusOK,
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        http.Header{headers.ContentType: {mime.JSON}},
+			ContentLength: int64(len(longBody)),
+			Body:          io.NopCloser(strings.NewReader(longBody)),
+		}
+	}
+
+	return []bodyLimitCase{
+		{
+			name:             "known length",
+			request:          post,
+			response:         respond,
+			expectedRequest:  "\r\n\r\n{\"name\":\"B\n... [truncated, original length: 41 bytes]",
+			expectedResponse: "\r\n\r\n{\"name\":\"B\n... [truncated, original length: 41 bytes]",
+			expectedSent:     longBody,
+			expectedReceived: longBody,
+			expectedSizes:    [2]int64{41, 41},
+		},
+		{
+			name: "unknown length",
+			request: func() *http.Request {
+				req := post()
+				req.Body = io.NopCloser(bytes.NewBufferString(longBody))
+				req.ContentLength = -1
+
+				return req
+			},
+			response: func() *http.Response {
+				res := respond()
+				res.ContentLength = -1
+
+				return res
+			},
+			expectedRequest:  "\r\n\r\n{\"name\":\"B\n... [truncated, original length: unknown]",
+			expectedResponse: "\r\n\r\n{\"name\":\"B\n... [truncated, original length: unknown]",
+			expectedSent:     longBody,
+			expectedReceived: longBody,
+			expectedSizes:    [2]int64{-1, -1},
+		},
+		{
+			name: "plain reader",
+			request: func() *http.Request {
+				req, _ := http.NewRequest(http.MethodPost, URL, io.MultiReader(strings.NewReader(longBody)))
+				req.Header.Set(headers.ContentType, mime.JSON)
+
+				return req
+			},
+			response:         respond,
+			expectedRequest:  "\r\n\r\n{\"name\":\"B\n... [truncated, original length: unknown]",
+			expectedResponse: "\r\n\r\n{\"name\":\"B\n... [truncated, original length: 41 bytes]",
+			expectedSent:     longBody,
+			expectedReceived: longBody,
+			expectedSizes:    [2]int64{-1, 41},
+		},
+		{
+			name: "short body",
+			request: func() *http.Request {
+				req, _ := http.NewRequest(http.MethodGet, URL, nil)
+				req.Header.Set(headers.ContentType, mime.JSON)
+
+				return req
+			},
+			response: func() *http.Response {
+				res := respond()
+				res.Body = io.NopCloser(strings.NewReader("{}"))
+				res.ContentLength = 2
+
+				return res
+			},
+			expectedRequest:  "Content-Type: application/json\r\nAccept-Encoding: gzip\r\n\r\n",
+			expectedResponse: "\r\n\r\n{}",
+			expectedReceived: "{}",
+			expectedSizes:    [2]int64{0, 2},
+		},
+		{
+			name: "read error",
+			request: func() *http.Request {
+				req := post()
+				req.Body = io.NopCloser(io.MultiReader(strings.NewReader(`{"na`), iotest.ErrReader(errors.New("boom"))))
+				req.ContentLength = -1
+
+				return req
+			},
+			response:         respond,
+			expectedResponse: "\r\n\r\n{\"name\":\"B\n... [truncated, original length: 41 bytes]",
+			expectedLog:      "HTTP request dump error: boom",
+			expectedSent:     `{"na`,
+			expectedReceived: longBody,
+			expectedSizes:    [2]int64{0, 41},
+		},
+		{
+			name: "dump request error",
+			request: func() *http.Request {
+				req, _ := http.NewRequest(http.MethodPost, "/ttt", strings.NewReader(longBody))
+				req.Header.Set(headers.ContentType, mime.JSON)
+
+				return req
+			},
+			response:         respond,
+			expectedResponse: "\r\n\r\n{\"name\":\"B\n... [truncated, original length: 41 bytes]",
+			expectedLog:      requestDumpErr,
+			expectedSent:     longBody,
+			expectedReceived: longBody,
+			expectedSizes:    [2]int64{0, 41},
+		},
+		{
+			name:             "dump response error",
+			request:          post,
+			response:         respond,
+			usePatch:         patchDumpResponse,
+			expectedLog:      responseDumpErr,
+			expectedSent:     longBody,
+			expectedReceived: longBody,
+			expectedSizes:    [2]int64{41, 0},
+		},
+	}
+}
@@ -3,9 +3,10 @@ package dumper
 
 import (
 	"context"
-	"fmt"
 	"net/http"
+	"net/http/httptrace"
 	"net/http/httputil"
+	"time"
 
 	"github.com/nafigator/http/headers"
 	"github.com/nafigator/http/mime"
@@ -23,6 +24,10 @@ type flusher interface {
 	Flush(ctx context.Context, msg string)
 }
 
+type recordFlusher interface {
+	FlushRecord(ctx context.Context, r Record)
+}
+
 type logger interface {
 	Error(args ...any)
 }
@@ -31,9 +36,13 @@ type HTTPDumper struct {
 	next     http.RoundTripper
 	masker   masker
 	flusher  flusher
+	recorder recordFlusher
 	log      logger
 	filter   func(string) bool
-	template string
+	render   func(Record) string
+	now      func() time.Time
+	limit    int64
+	timing   bool
 }
 
 // New creates http-dumper instance.
@@ -42,16 +51,47 @@ func New(
 	flusher flusher,
 ) *HTTPDumper {
 	return &HTTPDumper{
-		template: defaultTemplate,
-		next:     next,
-		flusher:  flusher,
-		filter:   needBody,
+		next:    next,
+		flusher: flusher,
+		filter:  needBody,
+		render:  Text(defaultTemplate),
+		now:     time.Now,
 	}
 }
 
 // WithTemplate initializes new custom output template.
 func (h *HTTPDumper) WithTemplate(t string) *HTTPDumper {
-	h.template = t
+	h.render = Text(t)
+
+	return h
+}
+
+// WithRenderer replaces text renderer that converts records into flusher messages.
+func (h *HTTPDumper) WithRenderer(r func(Record) string) *HTTPDumper {
+	h.render = r
+
+	return h
+}
+
+// WithRecordFlusher initializes flusher for structured records. When set, records are passed
+// to it instead of rendering into messages for flusher.
+func (h *HTTPDumper) WithRecordFlusher(f recordFlusher) *HTTPDumper {
+	h.recorder = f
+
+	return h
+}
+
+// WithTiming enables connection timing breakdown in dumps.
+func (h *HTTPDumper) WithTiming() *HTTPDumper {
+	h.timing = true
+
+	return h
+}
+
+// WithBodyLimit sets maximum size of dumped body in bytes. Longer bodies are truncated in dumps
+// but still streamed completely. Zero means no limit.
+func (h *HTTPDumper) WithBodyLimit(n int64) *HTTPDumper {
+	h.limit = n
 
 	return h
 }
@@ -79,7 +119,7 @@ func (h *HTTPDumper) WithFilter(f func(string) bool) *HTTPDumper {
 
 // RoundTrip [http.RoundTripper] implementation.
 func (h *HTTPDumper) RoundTrip(req *http.Request) (*http.Response, error) {
-	b, e := httputil.DumpRequestOut(req, h.filter(req.Header.Get(headers.ContentType)))
+	b, t, e := h.dumpRequest(req)
 	if e != nil {
 		if h.log != nil {
 			h.log.Error("HTTP request dump error: ", e)
@@ -87,7 +127,7 @@ func (h *HTTPDumper) RoundTrip(req *http.Request) (*http.Response, error) {
 
 		dump := ""
 
-		return h.handleRequest(req, dump)
+		return h.handleRequest(req, dump, truncation{})
 	}
 
 	dump := string(b)
@@ -95,46 +135,118 @@ func (h *HTTPDumper) RoundTrip(req *http.Request) (*http.Response, error) {
 		h.masker.Mask(req, &dump)
 	}
 
-	return h.handleRequest(req, dump)
+	return h.handleRequest(req, dump, t)
 }
 
-func (h *HTTPDumper) handleRequest(req *http.Request, reqDump string) (*http.Response, error) {
-	var e error
-	var res *http.Response
+func (h *HTTPDumper) handleRequest(req *http.Request, reqDump string, reqCut truncation) (*http.Response, error) {
 	ctx := req.Context()
+	rec := Record{
+		Method:      req.Method,
+		URL:         req.URL.String(),
+		RequestDump: reqDump,
+		Start:       h.now(),
+	}
+
+	if h.masker != nil {
+		h.masker.Mask(req, &rec.URL)
+	}
+
+	rec.RequestHeader, rec.RequestBody = parse(reqDump)
+	rec.RequestBodySize = reqCut.bodySize(rec.RequestBody)
+	rec.RequestTruncated = reqCut.truncated
+
+	var t *tracer
+	if h.timing {
+		t = newTracer(h.now)
+		req = req.WithContext(httptrace.WithClientTrace(ctx, t.trace()))
+	}
 
 	// Send request
-	res, e = h.next.RoundTrip(req)
+	res, e := h.next.RoundTrip(req)
+	rec.End = h.now()
+
+	if t != nil {
+		rec.Timing = t.timing(rec.Start, rec.End)
+	}
+
 	if e != nil {
-		msg := fmt.Sprintf(h.template, reqDump, e.Error())
-		h.flusher.Flush(ctx, msg)
+		rec.Err = e
+		h.flush(ctx, rec)
 
 		return res, e
 	}
 
-	var b []byte
+	rec.Status = res.StatusCode
 
-	b, e = httputil.DumpResponse(res, h.filter(res.Header.Get(headers.ContentType)))
+	b, resCut, e := h.dumpResponse(res)
 	if e != nil {
 		if h.log != nil {
 			h.log.Error("HTTP response dump error: ", e)
 		}
 
-		msg := fmt.Sprintf(h.template, reqDump, "")
-		h.flusher.Flush(ctx, msg)
+		h.flush(ctx, rec)
 
 		return res, nil
 	}
 
-	resDump := string(b)
+	rec.ResponseDump = string(b)
 	if h.masker != nil {
-		h.masker.Mask(req, &resDump)
+		h.masker.Mask(req, &rec.ResponseDump)
+	}
+
+	rec.ResponseHeader, rec.ResponseBody = parse(rec.ResponseDump)
+	rec.ResponseBodySize = resCut.bodySize(rec.ResponseBody)
+	rec.ResponseTruncated = resCut.truncated
+	h.flush(ctx, rec)
+
+	return res, nil
+}
+
+func (h *HTTPDumper) dumpRequest(req *http.Request) ([]byte, truncation, error) {
+	body := h.filter(req.Header.Get(headers.ContentType))
+	if !body || h.limit <= 0 {
+		b, e := httputil.DumpRequestOut(req, body)
+
+		return b, truncation{}, e
+	}
+
+	b, e := httputil.DumpRequestOut(req, false)
+	if e != nil {
+		return nil, truncation{}, e
+	}
+
+	length := req.ContentLength
+	if length <= 0 {
+		length = -1 // zero length with body means unknown for outgoing requests
 	}
 
-	msg := fmt.Sprintf(h.template, reqDump, resDump)
-	h.flusher.Flush(ctx, msg)
+	return appendHead(b, &req.Body, length, h.limit)
+}
+
+func (h *HTTPDumper) dumpResponse(res *http.Response) ([]byte, truncation, error) {
+	body := h.filter(res.Header.Get(headers.ContentType))
+	if !body || h.limit <= 0 {
+		b, e := httputil.DumpResponse(res, body)
+
+		return b, truncation{}, e
+	}
+
+	b, e := httputil.DumpResponse(res, false)
+	if e != nil {
+		return nil, truncation{}, e
+	}
+
+	return appendHead(b, &res.Body, res.ContentLength, h.limit)
+}
+
+func (h *HTTPDumper) flush(ctx context.Context, rec Record) {
+	if h.recorder != nil {
+		h.recorder.FlushRecord(ctx, rec)
+
+		return
+	}
 
-	return res, e
+	h.flusher.Flush(ctx, h.render(rec))
 }
 
 func needBody(ct string) bool {
@@ -0,0 +1,329 @@
+package dumper
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/nafigator/http/headers"
+)
+
+const (
+	harVersion     = "1.2"
+	harCreatorName = "github.com/nafigator/http/client/dumper"
+	harNotApplied  = -1
+	requestFields  = 3
+	cookieExpiries = time.RFC3339
+)
+
+type harDocument struct {
+	Log harLog `json:"log"`
+}
+
+type harLog struct {
+	Creator harCreator `json:"creator"`
+	Version string     `json:"version"`
+	Entries []harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	Cache    struct{}    `json:"cache"`
+	Started  time.Time   `json:"startedDateTime"`
+	Request  harRequest  `json:"request"`
+	Response harResponse `json:"response"`
+	Timings  harTimings  `json:"timings"`
+	Time     float64     `json:"time"`
+}
+
+type harRequest struct {
+	PostData    *harPostData `json:"postData,omitempty"`
+	Method      string       `json:"method"`
+	URL         string       `json:"url"`
+	HTTPVersion string       `json:"httpVersion"`
+	Cookies     []harCookie  `json:"cookies"`
+	Headers     []harPair    `json:"headers"`
+	QueryString []harPair    `json:"queryString"`
+	HeadersSize int          `json:"headersSize"`
+	BodySize    int64        `json:"bodySize"`
+}
+
+type harResponse struct {
+	StatusText  string      `json:"statusText"`
+	HTTPVersion string      `json:"httpVersion"`
+	RedirectURL string      `json:"redirectURL"`
+	Error       string      `json:"_error,omitempty"`
+	Content     harContent  `json:"content"`
+	Cookies     []harCookie `json:"cookies"`
+	Headers     []harPair   `json:"headers"`
+	Status      int         `json:"status"`
+	HeadersSize int         `json:"headersSize"`
+	BodySize    int64       `json:"bodySize"`
+}
+
+type harCookie struct {
+	Name     string `json:"name"`
+	Value    string `json:"value"`
+	Path     string `json:"path,omitempty"`
+	Domain   string `json:"domain,omitempty"`
+	Expires  string `json:"expires,omitempty"`
+	HTTPOnly bool   `json:"httpOnly,omitempty"`
+	Secure   bool   `json:"secure,omitempty"`
+}
+
+type harPair struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string    `json:"mimeType"`
+	Text     string    `json:"text"`
+	Params   []harPair `json:"params"`
+}
+
+type harContent struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+	Encoding string `json:"encoding,omitempty"`
+	Size     int64  `json:"size"`
+}
+
+type harTimings struct {
+	Blocked float64 `json:"blocked"`
+	DNS     float64 `json:"dns"`
+	Connect float64 `json:"connect"`
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+	SSL     float64 `json:"ssl"`
+}
+
+// HAR renders record as HAR 1.2 document with single entry.
+func HAR(r Record) string {
+	b, _ := json.Marshal(harDocument{
+		Log: harLog{
+			Creator: harCreator{Name: harCreatorName},
+			Version: harVersion,
+			Entries: []harEntry{newHAREntry(r)},
+		},
+	})
+
+	return string(b)
+}
+
+func newHAREntry(r Record) harEntry {
+	duration := milliseconds(r.Duration())
+	e := harEntry{
+		Started:  r.Start,
+		Request:  newHARRequest(r),
+		Response: newHARResponse(r),
+		Timings: harTimings{
+			Blocked: harNotApplied,
+			DNS:     harNotApplied,
+			Connect: harNotApplied,
+			SSL:     harNotApplied,
+			Wait:    duration,
+		},
+		Time: duration,
+	}
+
+	if r.Timing != nil {
+		e.Timings = newHARTimings(*r.Timing)
+	}
+
+	return e
+}
+
+// newHARTimings splits exchange time into HAR phases. Connect includes TLS handshake as HAR requires.
+func newHARTimings(t Timing) harTimings {
+	connect := t.Connect + t.TLS
+	wait := t.Total - t.DNS - connect
+	receive := time.Duration(0)
+
+	if t.TTFB > 0 {
+		wait = t.TTFB - t.DNS - connect
+		receive = t.Total - t.TTFB
+	}
+
+	return harTimings{
+		Blocked: harNotApplied,
+		DNS:     harPhase(t.DNS),
+		Connect: harPhase(connect),
+		SSL:     harPhase(t.TLS),
+		Wait:    milliseconds(max(wait, 0)),
+		Receive: milliseconds(max(receive, 0)),
+	}
+}
+
+func newHARRequest(r Record) harRequest {
+	req := harRequest{
+		Method:      r.Method,
+		URL:         r.URL,
+		HTTPVersion: requestProto(r.RequestDump),
+		Cookies:     requestCookies(r.RequestHeader),
+		Headers:     harHeaders(r.RequestHeader),
+		QueryString: harQuery(r.URL),
+		HeadersSize: harNotApplied,
+		BodySize:    r.RequestBodySize,
+	}
+
+	if len(r.RequestBody) > 0 {
+		req.PostData = &harPostData{
+			MimeType: r.RequestHeader.Get(headers.ContentType),
+			Text:     string(r.RequestBody),
+			Params:   []harPair{},
+		}
+	}
+
+	return req
+}
+
+func newHARResponse(r Record) harResponse {
+	res := harResponse{
+		StatusText:  http.StatusText(r.Status),
+		HTTPVersion: responseProto(r.ResponseDump),
+		RedirectURL: r.ResponseHeader.Get(headers.Location),
+		Content: harContent{
+			MimeType: r.ResponseHeader.Get(headers.ContentType),
+			Size:     r.ResponseBodySize,
+		},
+		Cookies:     responseCookies(r.ResponseHeader),
+		Headers:     harHeaders(r.ResponseHeader),
+		Status:      r.Status,
+		HeadersSize: harNotApplied,
+		BodySize:    r.ResponseBodySize,
+	}
+
+	res.Content.Text, res.Content.Encoding = encodeBody(r.ResponseBody)
+
+	if r.Err != nil {
+		res.Error = r.Err.Error()
+	}
+
+	return res
+}
+
+func harHeaders(h http.Header) []harPair {
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+
+	slices.Sort(keys)
+
+	pairs := make([]harPair, 0, len(h))
+	for _, k := range keys {
+		for _, v := range h[k] {
+			pairs = append(pairs, harPair{Name: k, Value: v})
+		}
+	}
+
+	return pairs
+}
+
+// harQuery lists query params in original order.
+func harQuery(rawURL string) []harPair {
+	pairs := []harPair{}
+
+	_, query, _ := strings.Cut(rawURL, "?")
+	query, _, _ = strings.Cut(query, "#")
+
+	for _, p := range strings.Split(query, "&") {
+		if p == "" {
+			continue
+		}
+
+		k, v, _ := strings.Cut(p, "=")
+		pairs = append(pairs, harPair{Name: unescape(k), Value: unescape(v)})
+	}
+
+	return pairs
+}
+
+func requestCookies(h http.Header) []harCookie {
+	cookies := (&http.Request{Header: h}).Cookies()
+	list := make([]harCookie, 0, len(cookies))
+
+	for _, c := range cookies {
+		list = append(list, harCookie{Name: c.Name, Value: c.Value})
+	}
+
+	return list
+}
+
+func responseCookies(h http.Header) []harCookie {
+	cookies := (&http.Response{Header: h}).Cookies()
+	list := make([]harCookie, 0, len(cookies))
+
+	for _, c := range cookies {
+		hc := harCookie{
+			Name:     c.Name,
+			Value:    c.Value,
+			Path:     c.Path,
+			Domain:   c.Domain,
+			HTTPOnly: c.HttpOnly,
+			Secure:   c.Secure,
+		}
+
+		if !c.Expires.IsZero() {
+			hc.Expires = c.Expires.Format(cookieExpiries)
+		}
+
+		list = append(list, hc)
+	}
+
+	return list
+}
+
+// requestProto extracts protocol version from request line like "GET / HTTP/1.1".
+func requestProto(dump string) string {
+	if f := startLine(dump); len(f) == requestFields {
+		return f[2]
+	}
+
+	return ""
+}
+
+// responseProto extracts protocol version from status line like "HTTP/1.1 200 OK".
+func responseProto(dump string) string {
+	if f := startLine(dump); len(f) > 0 {
+		return f[0]
+	}
+
+	return ""
+}
+
+func startLine(dump string) []string {
+	line, _, _ := strings.Cut(dump, "\r\n")
+
+	return strings.Fields(line)
+}
+
+func unescape(s string) string {
+	if u, e := url.QueryUnescape(s); e == nil {
+		return u
+	}
+
+	return s
+}
+
+// harPhase returns duration of phase or -1 if phase didn't happen.
+func harPhase(d time.Duration) float64 {
+	if d == 0 {
+		return harNotApplied
+	}
+
+	return milliseconds(d)
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,93 @@
+package dumper
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	encodingBase64 = "base64"
+)
+
+type jsonRecord struct {
+	Start    time.Time   `json:"start"`
+	End      time.Time   `json:"end"`
+	Timing   *jsonTiming `json:"timing,omitempty"`
+	Method   string      `json:"method"`
+	URL      string      `json:"url"`
+	Error    string      `json:"error,omitempty"`
+	Request  jsonMessage `json:"request"`
+	Response jsonMessage `json:"response"`
+	Duration float64     `json:"duration_ms"`
+	Status   int         `json:"status,omitempty"`
+}
+
+type jsonTiming struct {
+	DNS     float64 `json:"dns_ms"`
+	Connect float64 `json:"connect_ms"`
+	TLS     float64 `json:"tls_ms"`
+	TTFB    float64 `json:"ttfb_ms"`
+	Total   float64 `json:"total_ms"`
+	Reused  bool    `json:"reused"`
+}
+
+type jsonMessage struct {
+	Headers   http.Header `json:"headers,omitempty"`
+	Body      string      `json:"body,omitempty"`
+	Encoding  string      `json:"encoding,omitempty"`
+	Size      int64       `json:"size,omitempty"`
+	Truncated bool        `json:"truncated,omitempty"`
+}
+
+// JSON renders record as single-line JSON object. Binary bodies are encoded to base64. Truncated bodies
+// are flagged and keep original length in size field.
+func JSON(r Record) string {
+	rec := jsonRecord{
+		Start:    r.Start,
+		End:      r.End,
+		Method:   r.Method,
+		URL:      r.URL,
+		Request:  newJSONMessage(r.RequestHeader, r.RequestBody, r.RequestBodySize, r.RequestTruncated),
+		Response: newJSONMessage(r.ResponseHeader, r.ResponseBody, r.ResponseBodySize, r.ResponseTruncated),
+		Duration: milliseconds(r.Duration()),
+		Status:   r.Status,
+	}
+
+	if r.Err != nil {
+		rec.Error = r.Err.Error()
+	}
+
+	if t := r.Timing; t != nil {
+		rec.Timing = &jsonTiming{
+			DNS:     milliseconds(t.DNS),
+			Connect: milliseconds(t.Connect),
+			TLS:     milliseconds(t.TLS),
+			TTFB:    milliseconds(t.TTFB),
+			Total:   milliseconds(t.Total),
+			Reused:  t.Reused,
+		}
+	}
+
+	b, _ := json.Marshal(rec)
+
+	return string(b)
+}
+
+func newJSONMessage(h http.Header, body []byte, size int64, truncated bool) jsonMessage {
+	m := jsonMessage{Headers: h, Size: size, Truncated: truncated}
+	m.Body, m.Encoding = encodeBody(body)
+
+	return m
+}
+
+// encodeBody returns body as text or base64 string with encoding name for binary data.
+func encodeBody(body []byte) (string, string) {
+	if utf8.Valid(body) {
+		return string(body), ""
+	}
+
+	return base64.StdEncoding.EncodeToString(body), encodingBase64
+}
@@ -0,0 +1,96 @@
+package dumper
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"net/textproto"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/nafigator/http/headers"
+)
+
+// Record describes single dumped HTTP exchange.
+//
+// Headers and bodies are parsed from masked dumps, so masker is applied to every field.
+type Record struct {
+	// Start is a moment when request was passed to the next [http.RoundTripper].
+	Start time.Time
+	// End is a moment when response headers or transport error were received.
+	End time.Time
+	// Err is a transport error returned by the next [http.RoundTripper].
+	Err error
+	// Timing is a connection timing breakdown. It is nil unless timing is enabled by [HTTPDumper.WithTiming].
+	Timing         *Timing
+	RequestHeader  http.Header
+	ResponseHeader http.Header
+	Method         string
+	URL            string
+	// RequestDump is a masked wire-format request dump.
+	RequestDump string
+	// ResponseDump is a masked wire-format response dump.
+	ResponseDump string
+	RequestBody  []byte
+	ResponseBody []byte
+	// RequestBodySize is an original length of request body, -1 if body is truncated and length is unknown.
+	RequestBodySize int64
+	// ResponseBodySize is an original length of response body, -1 if body is truncated and length is unknown.
+	ResponseBodySize int64
+	Status           int
+	// RequestTruncated reports that RequestBody holds only head of body cut by [HTTPDumper.WithBodyLimit].
+	RequestTruncated bool
+	// ResponseTruncated reports that ResponseBody holds only head of body cut by [HTTPDumper.WithBodyLimit].
+	ResponseTruncated bool
+}
+
+// Duration returns time spent between request and response.
+func (r Record) Duration() time.Duration {
+	return r.End.Sub(r.Start)
+}
+
+// Text returns renderer that formats record dumps by template. First placeholder is for request, second for response.
+// Truncated bodies are followed by marker with original length.
+func Text(template string) func(Record) string {
+	return func(r Record) string {
+		req := r.RequestDump + truncationMarker(r.RequestTruncated, r.RequestBodySize)
+		res := r.ResponseDump + truncationMarker(r.ResponseTruncated, r.ResponseBodySize)
+
+		if r.Err != nil {
+			res = r.Err.Error()
+		}
+
+		if r.Timing != nil {
+			res += "\n\n" + r.Timing.String()
+		}
+
+		return fmt.Sprintf(template, req, res)
+	}
+}
+
+// parse splits wire-format dump into headers and decoded body.
+func parse(dump string) (http.Header, []byte) {
+	head, body, found := strings.Cut(dump, "\r\n\r\n")
+	if !found {
+		return nil, nil
+	}
+
+	_, fields, _ := strings.Cut(head, "\r\n")
+	r := textproto.NewReader(bufio.NewReader(strings.NewReader(fields + "\r\n\r\n")))
+	h, _ := r.ReadMIMEHeader()
+
+	if body == "" {
+		return http.Header(h), nil
+	}
+
+	if slices.Contains(h.Values(headers.TransferEncoding), "chunked") {
+		if b, e := io.ReadAll(httputil.NewChunkedReader(strings.NewReader(body))); e == nil {
+			return http.Header(h), b
+		}
+	}
+
+	return http.Header(h), []byte(body)
+}
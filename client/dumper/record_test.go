@@ -0,0 +1,186 @@
+package dumper
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/nafigator/http/headers"
+	"github.com/nafigator/http/masker/query"
+	"github.com/nafigator/http/mime"
+)
+
+const (
+	unexpectedRecord  = "Unexpected record"
+	unexpectedHeader  = "Unexpected header"
+	unexpectedBody    = "Unexpected body"
+	unexpectedMessage = "Unexpected message"
+)
+
+type recorder struct {
+	records []Record
+}
+
+type messages struct {
+	list []string
+}
+
+type recordCase struct {
+	expectedError    error
+	responseRecorder *httptest.ResponseRecorder
+	expectedHeader   http.Header
+	name             string
+	expectedURL      string
+	expectedBody     []byte
+	expectedStatus   int
+}
+
+type parseCase struct {
+	expectedHeader http.Header
+	name           string
+	dump           string
+	expectedBody   []byte
+}
+
+func (r *recorder) FlushRecord(_ context.Context, rec Record) {
+	r.records = append(r.records, rec)
+}
+
+func (m *messages) Flush(_ context.Context, msg string) {
+	m.list = append(m.list, msg)
+}
+
+func (s *suite) TestRecord() {
+	for _, c := range recordProvider() {
+		s.Run(c.name, func() {
+			ctrl := gomock.NewController(s.T())
+			next := NewMockRoundTripper(ctrl)
+			rec := &recorder{}
+			start := time.Date(2025, 1, 8, 9, 18, 29, 0, time.UTC)
+			moments := []time.Time{start, start.Add(time.Second)}
+
+			d := New(next, nil).
+				WithRecordFlusher(rec).
+				WithMasker(query.New([]string{"secret"}))
+			d.now = func() time.Time {
+				t := moments[0]
+				moments = moments[1:]
+
+				return t
+			}
+
+			req, _ := http.NewRequest(http.MethodPost, URL+"/?secret=0123456789ABC", bytes.NewBufferString(`{"a":1}`))
+			req.Header.Set(headers.ContentType, mime.JSON)
+
+			next.EXPECT().
+				RoundTrip(req).
+				Return(c.responseRecorder.Result(), c.expectedError).
+				Times(1)
+
+			_, _ = d.RoundTrip(req)
+
+			s.Require().Len(rec.records, 1, unexpectedMsgCount)
+			actual := rec.records[0]
+
+			s.Equal(http.MethodPost, actual.Method, unexpectedRecord)
+			s.Equal(c.expectedURL, actual.URL, unexpectedRecord)
+			s.Equal(c.expectedStatus, actual.Status, unexpectedRecord)
+			s.Equal(c.expectedError, actual.Err, unexpectedRecord)
+			s.Equal(time.Second, actual.Duration(), unexpectedRecord)
+			s.Equal(mime.JSON, actual.RequestHeader.Get(headers.ContentType), unexpectedHeader)
+			s.Equal([]byte(`{"a":1}`), actual.RequestBody, unexpectedBody)
+			s.Equal(c.expectedHeader, actual.ResponseHeader, unexpectedHeader)
+			s.Equal(c.expectedBody, actual.ResponseBody, unexpectedBody)
+		})
+	}
+}
+
+func (s *suite) TestRenderer() {
+	ctrl := gomock.NewController(s.T())
+	next := NewMockRoundTripper(ctrl)
+	m := &messages{}
+
+	d := New(next, m).WithRenderer(func(r Record) string {
+		return r.Method + " " + r.URL
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, URL, nil)
+
+	next.EXPECT().
+		RoundTrip(req).
+		Return(httptest.NewRecorder().Result(), nil).
+		Times(1)
+
+	_, _ = d.RoundTrip(req)
+
+	s.Equal([]string{"GET https://localhost"}, m.list, unexpectedMessage)
+}
+
+func (s *suite) TestParse() {
+	for _, c := range parseProvider() {
+		s.Run(c.name, func() {
+			h, b := parse(c.dump)
+
+			s.Equal(c.expectedHeader, h, unexpectedHeader)
+			s.Equal(c.expectedBody, b, unexpectedBody)
+		})
+	}
+}
+
+func recordProvider() []recordCase {
+	res := httptest.NewRecorder()
+	res.Header().Set(headers.ContentType, mime.JSON)
+	res.Header().Set(headers.ContentLength, "11")
+	res.Body = bytes.NewBufferString(`{"ok":true}`)
+
+	return []recordCase{
+		{
+			name:             "200 response",
+			responseRecorder: res,
+			expectedURL:      URL + "/?secret=******6789ABC",
+			expectedStatus:   http.StatusOK,
+			expectedHeader: http.Header{
+				headers.ContentType:   {mime.JSON},
+				headers.ContentLength: {"11"},
+			},
+			expectedBody: []byte(`{"ok":true}`),
+		},
+		{
+			name:             "next RoundTrip returns error",
+			responseRecorder: httptest.NewRecorder(),
+			expectedError:    errors.New("internal error"),
+			expectedURL:      URL + "/?secret=******6789ABC",
+		},
+	}
+}
+
+func parseProvider() []parseCase {
+	return []parseCase{
+		{
+			name: "incomplete dump",
+			dump: "HTTP/1.1 200 OK\r\n",
+		},
+		{
+			name:           "without headers and body",
+			dump:           "HTTP/1.1 200 OK\r\n\r\n",
+			expectedHeader: http.Header{},
+		},
+		{
+			name:           "chunked body",
+			dump:           "HTTP/1.1 200 OK\r\nTransfer-Encoding: chunked\r\n\r\n3\r\nfoo\r\n0\r\n\r\n",
+			expectedHeader: http.Header{headers.TransferEncoding: {"chunked"}},
+			expectedBody:   []byte("foo"),
+		},
+		{
+			name:           "malformed chunked body",
+			dump:           "HTTP/1.1 200 OK\r\nTransfer-Encoding: chunked\r\n\r\nfoo",
+			expectedHeader: http.Header{headers.TransferEncoding: {"chunked"}},
+			expectedBody:   []byte("foo"),
+		},
+	}
+}
@@ -0,0 +1,115 @@
+package dumper
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// Timing describes where time of HTTP exchange went. Phases that didn't happen are zero.
+type Timing struct {
+	// DNS is a DNS lookup duration.
+	DNS time.Duration
+	// Connect is a TCP connect duration.
+	Connect time.Duration
+	// TLS is a TLS handshake duration.
+	TLS time.Duration
+	// TTFB is a time from request start to the first response byte.
+	TTFB time.Duration
+	// Total is a time from request start to received response headers.
+	Total time.Duration
+	// Reused is true when request was sent over idle keep-alive connection.
+	Reused bool
+}
+
+type tracer struct {
+	now          func() time.Time
+	dnsStart     time.Time
+	dnsDone      time.Time
+	connectStart time.Time
+	connectDone  time.Time
+	tlsStart     time.Time
+	tlsDone      time.Time
+	firstByte    time.Time
+	mu           sync.Mutex
+	reused       bool
+}
+
+// String formats timing for text dumps.
+func (t Timing) String() string {
+	return fmt.Sprintf(
+		"Timing: dns=%s connect=%s tls=%s ttfb=%s total=%s reused=%t",
+		t.DNS, t.Connect, t.TLS, t.TTFB, t.Total, t.Reused,
+	)
+}
+
+func newTracer(now func() time.Time) *tracer {
+	return &tracer{now: now}
+}
+
+// trace returns hooks that collect connection phase moments.
+func (t *tracer) trace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) {
+			t.mark(&t.dnsStart)
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			t.mark(&t.dnsDone)
+		},
+		ConnectStart: func(_, _ string) {
+			t.mark(&t.connectStart)
+		},
+		ConnectDone: func(_, _ string, _ error) {
+			t.mark(&t.connectDone)
+		},
+		TLSHandshakeStart: func() {
+			t.mark(&t.tlsStart)
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			t.mark(&t.tlsDone)
+		},
+		GotConn: func(i httptrace.GotConnInfo) {
+			t.mu.Lock()
+			t.reused = i.Reused
+			t.mu.Unlock()
+		},
+		GotFirstResponseByte: func() {
+			t.mark(&t.firstByte)
+		},
+	}
+}
+
+// timing calculates phase durations of exchange between start and end moments.
+func (t *tracer) timing(start, end time.Time) *Timing {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return &Timing{
+		DNS:     between(t.dnsStart, t.dnsDone),
+		Connect: between(t.connectStart, t.connectDone),
+		TLS:     between(t.tlsStart, t.tlsDone),
+		TTFB:    between(start, t.firstByte),
+		Total:   end.Sub(start),
+		Reused:  t.reused,
+	}
+}
+
+// mark saves current moment. First moment wins, so parallel dials don't shift phase start.
+func (t *tracer) mark(moment *time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if moment.IsZero() {
+		*moment = t.now()
+	}
+}
+
+func between(from, to time.Time) time.Duration {
+	if from.IsZero() || to.IsZero() {
+		return 0
+	}
+
+	return to.Sub(from)
+}
@@ -0,0 +1,128 @@
+package dumper
+
+import (
+	"crypto/tls"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httptrace"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	unexpectedTiming = "Unexpected timing"
+)
+
+type harTimingsCase struct {
+	name     string
+	timing   Timing
+	expected harTimings
+}
+
+func (s *suite) TestTiming() {
+	ctrl := gomock.NewController(s.T())
+	next := NewMockRoundTripper(ctrl)
+	rec := &recorder{}
+	moment := time.Date(2025, 1, 8, 9, 18, 29, 0, time.UTC)
+
+	d := New(next, nil).WithRecordFlusher(rec).WithTiming()
+	d.now = func() time.Time {
+		moment = moment.Add(time.Millisecond)
+
+		return moment
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, URL, nil)
+
+	next.EXPECT().
+		RoundTrip(gomock.Any()).
+		DoAndReturn(func(r *http.Request) (*http.Response, error) {
+			t := httptrace.ContextClientTrace(r.Context())
+			t.DNSStart(httptrace.DNSStartInfo{})
+			t.DNSDone(httptrace.DNSDoneInfo{})
+			t.ConnectStart("tcp", "127.0.0.1:443")
+			t.ConnectStart("tcp", "[::1]:443")
+			t.ConnectDone("tcp", "127.0.0.1:443", nil)
+			t.TLSHandshakeStart()
+			t.TLSHandshakeDone(tls.ConnectionState{}, nil)
+			t.GotConn(httptrace.GotConnInfo{Reused: true})
+			t.GotFirstResponseByte()
+
+			return httptest.NewRecorder().Result(), nil
+		}).
+		Times(1)
+
+	_, _ = d.RoundTrip(req)
+
+	s.Require().Len(rec.records, 1, unexpectedMsgCount)
+	s.Equal(&Timing{
+		DNS:     time.Millisecond,
+		Connect: time.Millisecond,
+		TLS:     time.Millisecond,
+		TTFB:    7 * time.Millisecond,
+		Total:   8 * time.Millisecond,
+		Reused:  true,
+	}, rec.records[0].Timing, unexpectedTiming)
+}
+
+func (s *suite) TestTimingReused() {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("OK"))
+	}))
+	defer srv.Close()
+
+	m := &messages{}
+	transport := &http.Transport{}
+	defer transport.CloseIdleConnections()
+
+	c := http.Client{Transport: New(transport, m).WithTiming()}
+
+	for range 2 {
+		res, err := c.Get(srv.URL)
+		s.Require().NoError(err, unexpectedError)
+
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+	}
+
+	s.Require().Len(m.list, 2, unexpectedMsgCount)
+	s.Contains(m.list[0], "reused=false\n", unexpectedTiming)
+	s.Contains(m.list[1], "reused=true\n", unexpectedTiming)
+	s.Contains(m.list[1], "Timing: dns=0s connect=0s tls=0s ttfb=", unexpectedTiming)
+}
+
+func (s *suite) TestHARTimings() {
+	for _, c := range harTimingsProvider() {
+		s.Run(c.name, func() {
+			s.Equal(c.expected, newHARTimings(c.timing), unexpectedTiming)
+		})
+	}
+}
+
+func harTimingsProvider() []harTimingsCase {
+	return []harTimingsCase{
+		{
+			name: "new connection",
+			timing: Timing{
+				DNS:     time.Millisecond,
+				Connect: 2 * time.Millisecond,
+				TLS:     3 * time.Millisecond,
+				TTFB:    10 * time.Millisecond,
+				Total:   12 * time.Millisecond,
+			},
+			expected: harTimings{Blocked: -1, DNS: 1, Connect: 5, SSL: 3, Wait: 4, Receive: 2},
+		},
+		{
+			name:     "reused connection",
+			timing:   Timing{TTFB: 10 * time.Millisecond, Total: 10 * time.Millisecond, Reused: true},
+			expected: harTimings{Blocked: -1, DNS: -1, Connect: -1, SSL: -1, Wait: 10},
+		},
+		{
+			name:     "without response",
+			timing:   Timing{DNS: time.Millisecond, Total: 3 * time.Millisecond},
+			expected: harTimings{Blocked: -1, DNS: 1, Connect: -1, SSL: -1, Wait: 2},
+		},
+	}
+}
@@ -0,0 +1,148 @@
+package hedge
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// result holds response of single request copy.
+type result struct {
+	res     *http.Response
+	err     error
+	latency time.Duration
+	idx     int
+}
+
+// call holds state of single hedged request processing.
+type call struct {
+	h       *HTTPHedge
+	req     *http.Request
+	results chan result
+	cancels []context.CancelFunc
+	pending int
+}
+
+// newCall prepares hedged request processing.
+func (h *HTTPHedge) newCall(req *http.Request) *call {
+	return &call{
+		h:       h,
+		req:     req,
+		results: make(chan result, h.copies+1),
+		cancels: make([]context.CancelFunc, 0, h.copies+1),
+	}
+}
+
+// run sends request copies until acceptable response received or copies exhausted. Returns the last response when
+// none of them is acceptable.
+func (c *call) run() (*http.Response, error) {
+	c.launch()
+
+	delay := c.h.pause()
+	t := time.NewTimer(delay)
+	defer t.Stop()
+
+	var last *result
+
+	for c.pending > 0 {
+		select {
+		case r := <-c.results:
+			c.pending--
+
+			if last != nil {
+				c.lose(*last)
+			}
+
+			if c.h.validate(r.res, r.err) {
+				c.h.latency.observe(r.latency)
+
+				return c.win(r)
+			}
+
+			last = &r
+
+			if c.pending == 0 && c.more() {
+				c.launch()
+				t.Reset(delay)
+			}
+		case <-t.C:
+			if c.more() {
+				c.launch()
+				t.Reset(delay)
+			}
+		}
+	}
+
+	return c.win(*last)
+}
+
+// more reports whether next request copy may be sent.
+func (c *call) more() bool {
+	return len(c.cancels) <= c.h.copies
+}
+
+// launch sends next request copy.
+func (c *call) launch() {
+	ctx, cancel := context.WithCancel(c.req.Context())
+	idx := len(c.cancels)
+	c.cancels = append(c.cancels, cancel)
+	c.pending++
+
+	r := c.req.Clone(ctx)
+
+	go func() {
+		start := time.Now()
+		res, err := c.send(r, idx)
+
+		c.results <- result{
+			res:     res,
+			err:     err,
+			latency: time.Since(start),
+			idx:     idx,
+		}
+	}()
+}
+
+// send makes request copy. Copies except the first one get body from [http.Request.GetBody].
+func (c *call) send(r *http.Request, idx int) (*http.Response, error) {
+	if idx > 0 && r.GetBody != nil {
+		body, err := r.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("request body rewind: %w", err)
+		}
+
+		r.Body = body
+	}
+
+	return c.h.next.RoundTrip(r)
+}
+
+// win cancels other request copies and returns response. Copy context is canceled after response body closing.
+func (c *call) win(r result) (*http.Response, error) {
+	for i, cancel := range c.cancels {
+		if i != r.idx {
+			cancel()
+		}
+	}
+
+	if c.pending > 0 {
+		go c.drain(c.pending)
+	}
+
+	return finish(r.res, r.err, c.cancels[r.idx])
+}
+
+// lose discards response of request copy.
+func (c *call) lose(r result) {
+	discard(r.res, c.h.drainLimit)
+	c.cancels[r.idx]()
+}
+
+// drain discards responses of canceled request copies.
+func (c *call) drain(n int) {
+	for range n {
+		r := <-c.results
+		discard(r.res, c.h.drainLimit)
+	}
+}
@@ -0,0 +1,116 @@
+// Package hedge provides hedged requests functionality for [http.Client].
+package hedge
+
+import (
+	"net/http"
+	"time"
+)
+
+const (
+	defaultDelay      = 100 * time.Millisecond
+	defaultCopies     = 1
+	defaultSamples    = 100
+	defaultDrainLimit = 4 << 10
+)
+
+type HTTPHedge struct {
+	next       http.RoundTripper
+	validate   func(res *http.Response, err error) bool
+	latency    *latency
+	delay      time.Duration
+	percentile float64
+	drainLimit int64
+	copies     int
+}
+
+// New creates http hedged requests instance.
+func New(
+	next http.RoundTripper,
+) *HTTPHedge {
+	return &HTTPHedge{
+		next:       next,
+		validate:   ValidateDefault,
+		latency:    newLatency(defaultSamples),
+		delay:      defaultDelay,
+		drainLimit: defaultDrainLimit,
+		copies:     defaultCopies,
+	}
+}
+
+// RoundTrip [http.RoundTripper] implementation.
+func (h *HTTPHedge) RoundTrip(req *http.Request) (*http.Response, error) {
+	if h.copies <= 0 || !hedgeable(req) {
+		return h.next.RoundTrip(req)
+	}
+
+	return h.newCall(req).run()
+}
+
+// ValidateDefault validator accepts responses without transport errors and 5xx statuses.
+func ValidateDefault(res *http.Response, err error) bool {
+	return err == nil && res != nil && res.StatusCode < http.StatusInternalServerError
+}
+
+// WithDelay sets pause before sending next request copy. It is also used as fallback for percentile delay until
+// enough latency samples collected.
+func (h *HTTPHedge) WithDelay(delay time.Duration) *HTTPHedge {
+	h.delay = delay
+
+	return h
+}
+
+// WithPercentile sets pause before sending next request copy to given percentile of recent latencies, e.g. 95.
+// Zero value disables percentile delay.
+func (h *HTTPHedge) WithPercentile(p float64) *HTTPHedge {
+	h.percentile = p
+
+	return h
+}
+
+// WithCopies sets maximum count of additional request copies. Zero value disables hedging.
+func (h *HTTPHedge) WithCopies(n int) *HTTPHedge {
+	h.copies = n
+
+	return h
+}
+
+// WithRespValidator sets validator for responses. Response is accepted on true return. Validators of retry package
+// are compatible.
+func (h *HTTPHedge) WithRespValidator(f func(res *http.Response, err error) bool) *HTTPHedge {
+	h.validate = f
+
+	return h
+}
+
+// WithDrainLimit sets maximum bytes count read from discarded response bodies before closing, so connections can
+// be reused. Zero value disables draining.
+func (h *HTTPHedge) WithDrainLimit(limit int64) *HTTPHedge {
+	h.drainLimit = limit
+
+	return h
+}
+
+// pause returns delay before next request copy.
+func (h *HTTPHedge) pause() time.Duration {
+	if h.percentile <= 0 {
+		return h.delay
+	}
+
+	if d, ok := h.latency.percentile(h.percentile); ok {
+		return d
+	}
+
+	return h.delay
+}
+
+// hedgeable reports whether request may be sent several times: its method is safe by RFC 9110 and its body can be
+// replayed.
+func hedgeable(req *http.Request) bool {
+	switch req.Method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+	default:
+		return false
+	}
+
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
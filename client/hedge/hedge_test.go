@@ -0,0 +1,371 @@
+package hedge
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	unexpectedError    = "Unexpected error"
+	unexpectedResponse = "Unexpected response"
+	unexpectedCalls    = "Unexpected calls count"
+	unexpectedCanceled = "Unexpected canceled copies count"
+	unexpectedPause    = "Unexpected pause"
+	URL                = "https://localhost"
+	payload            = "payload"
+	longDelay          = time.Hour
+	shortDelay         = 10 * time.Millisecond
+)
+
+var errRewind = errors.New("rewind")
+
+// attempt describes behavior of request copy.
+type attempt struct {
+	err    error
+	status int
+	block  bool
+	closed bool // response body must be closed before RoundTrip returns
+}
+
+type roundTripCase struct {
+	expectedErr      error
+	request          func() *http.Request
+	name             string
+	attempts         []attempt
+	delay            time.Duration
+	copies           int
+	expectedStatus   int
+	expectedCalls    int32
+	expectedCanceled int32
+}
+
+func (s *suite) TestRoundTrip() {
+	for _, c := range roundTripProvider() {
+		s.Run(c.name, func() {
+			ctrl := gomock.NewController(s.T())
+			next := NewMockRoundTripper(ctrl)
+
+			var calls, canceled atomic.Int32
+
+			done := make(chan struct{}, len(c.attempts))
+			bodies := make([]*countReader, len(c.attempts))
+
+			h := New(next).
+				WithDelay(c.delay).
+				WithCopies(c.copies)
+
+			next.EXPECT().
+				RoundTrip(gomock.Any()).
+				DoAndReturn(func(req *http.Request) (*http.Response, error) {
+					idx := calls.Add(1) - 1
+					a := c.attempts[idx]
+
+					if req.Body != nil {
+						b, _ := io.ReadAll(req.Body)
+						s.Equal(payload, string(b), unexpectedResponse)
+					}
+
+					if a.block {
+						<-req.Context().Done()
+						canceled.Add(1)
+						done <- struct{}{}
+
+						return nil, req.Context().Err()
+					}
+
+					if a.err != nil {
+						return nil, a.err
+					}
+
+					rec := httptest.NewRecorder()
+					rec.WriteHeader(a.status)
+					_, _ = rec.WriteString(payload)
+
+					res := rec.Result()
+					bodies[idx] = &countReader{Reader: res.Body}
+					res.Body = bodies[idx]
+
+					return res, nil
+				}).
+				Times(int(c.expectedCalls))
+
+			res, err := h.RoundTrip(c.request())
+
+			for i, a := range c.attempts {
+				if a.closed {
+					s.True(bodies[i].closed, unexpectedResponse)
+				}
+			}
+
+			if c.expectedErr != nil {
+				s.Require().ErrorIs(err, c.expectedErr, unexpectedError)
+				s.Nil(res, unexpectedResponse)
+			} else {
+				s.Require().NoError(err, unexpectedError)
+				s.Equal(c.expectedStatus, res.StatusCode, unexpectedResponse)
+
+				b, readErr := io.ReadAll(res.Body)
+				s.Require().NoError(readErr, unexpectedError)
+				s.Equal(payload, string(b), unexpectedResponse)
+				s.Require().NoError(res.Body.Close(), unexpectedError)
+			}
+
+			for range c.expectedCanceled {
+				<-done
+			}
+
+			s.Equal(c.expectedCalls, calls.Load(), unexpectedCalls)
+			s.Equal(c.expectedCanceled, canceled.Load(), unexpectedCanceled)
+		})
+	}
+}
+
+func (s *suite) TestWinnerContext() {
+	ctrl := gomock.NewController(s.T())
+	next := NewMockRoundTripper(ctrl)
+
+	var ctx context.Context
+
+	h := New(next).WithDelay(longDelay)
+
+	next.EXPECT().
+		RoundTrip(gomock.Any()).
+		DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			ctx = req.Context()
+
+			return httptest.NewRecorder().Result(), nil
+		})
+
+	res, err := h.RoundTrip(request(http.MethodGet, false))
+	s.Require().NoError(err, unexpectedError)
+	s.Require().NoError(ctx.Err(), unexpectedError)
+	s.Require().NoError(res.Body.Close(), unexpectedError)
+	s.Require().ErrorIs(ctx.Err(), context.Canceled, unexpectedError)
+}
+
+func (s *suite) TestWithRespValidator() {
+	ctrl := gomock.NewController(s.T())
+	next := NewMockRoundTripper(ctrl)
+
+	h := New(next).
+		WithDelay(longDelay).
+		WithRespValidator(func(res *http.Response, _ error) bool {
+			return res != nil && res.StatusCode != http.StatusTooManyRequests
+		})
+
+	next.EXPECT().
+		RoundTrip(gomock.Any()).
+		DoAndReturn(func(_ *http.Request) (*http.Response, error) {
+			rec := httptest.NewRecorder()
+			rec.WriteHeader(http.StatusTooManyRequests)
+
+			return rec.Result(), nil
+		})
+
+	next.EXPECT().
+		RoundTrip(gomock.Any()).
+		DoAndReturn(func(_ *http.Request) (*http.Response, error) {
+			rec := httptest.NewRecorder()
+			rec.WriteHeader(http.StatusServiceUnavailable)
+
+			return rec.Result(), nil
+		})
+
+	res, err := h.RoundTrip(request(http.MethodGet, false))
+	s.Require().NoError(err, unexpectedError)
+	s.Equal(http.StatusServiceUnavailable, res.StatusCode, unexpectedResponse)
+}
+
+func (s *suite) TestPause() {
+	h := New(nil).WithDelay(shortDelay)
+	s.Equal(shortDelay, h.pause(), unexpectedPause)
+
+	h.WithPercentile(50)
+	s.Equal(shortDelay, h.pause(), unexpectedPause)
+
+	for i := range minSamples {
+		h.latency.observe(time.Duration(i+1) * time.Millisecond)
+	}
+
+	s.Equal(5*time.Millisecond, h.pause(), unexpectedPause)
+}
+
+func (s *suite) TestDrainLimit() {
+	body := &countReader{Reader: strings.NewReader(strings.Repeat(payload, count))}
+	h := New(nil).WithDrainLimit(int64(len(payload)))
+
+	discard(&http.Response{Body: body}, h.drainLimit)
+
+	s.Equal(len(payload), body.read, unexpectedResponse)
+	s.True(body.closed, unexpectedResponse)
+}
+
+func request(method string, withBody bool) *http.Request {
+	if !withBody {
+		req, _ := http.NewRequestWithContext(context.Background(), method, URL, nil)
+
+		return req
+	}
+
+	req, _ := http.NewRequestWithContext(context.Background(), method, URL, strings.NewReader(payload))
+
+	return req
+}
+
+// countReader counts read bytes.
+type countReader struct {
+	io.Reader
+
+	read   int
+	closed bool
+}
+
+func (r *countReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.read += n
+
+	return n, err
+}
+
+func (r *countReader) Close() error {
+	r.closed = true
+
+	return nil
+}
+
+func roundTripProvider() []roundTripCase {
+	get := func() *http.Request {
+		return request(http.MethodGet, false)
+	}
+
+	return []roundTripCase{
+		{
+			name:           "fast response",
+			request:        get,
+			delay:          longDelay,
+			copies:         1,
+			attempts:       []attempt{{status: http.StatusOK}},
+			expectedStatus: http.StatusOK,
+			expectedCalls:  1,
+		},
+		{
+			name:             "slow response",
+			request:          get,
+			delay:            shortDelay,
+			copies:           2,
+			attempts:         []attempt{{block: true}, {block: true}, {status: http.StatusOK}},
+			expectedStatus:   http.StatusOK,
+			expectedCalls:    3,
+			expectedCanceled: 2,
+		},
+		{
+			name:           "unacceptable response",
+			request:        get,
+			delay:          longDelay,
+			copies:         2,
+			attempts:       []attempt{{status: http.StatusBadGateway}, {status: http.StatusNotFound}},
+			expectedStatus: http.StatusNotFound,
+			expectedCalls:  2,
+		},
+		{
+			name:           "failed then accepted response",
+			request:        get,
+			delay:          longDelay,
+			copies:         1,
+			attempts:       []attempt{{status: http.StatusInternalServerError, closed: true}, {status: http.StatusOK}},
+			expectedStatus: http.StatusOK,
+			expectedCalls:  2,
+		},
+		{
+			name:    "all unacceptable responses",
+			request: get,
+			delay:   longDelay,
+			copies:  2,
+			attempts: []attempt{
+				{status: http.StatusBadGateway},
+				{err: io.EOF},
+				{status: http.StatusServiceUnavailable},
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedCalls:  3,
+		},
+		{
+			name:          "transport errors",
+			request:       get,
+			delay:         longDelay,
+			copies:        1,
+			attempts:      []attempt{{status: http.StatusBadGateway}, {err: io.EOF}},
+			expectedErr:   io.EOF,
+			expectedCalls: 2,
+		},
+		{
+			name: "replayable body",
+			request: func() *http.Request {
+				return request(http.MethodGet, true)
+			},
+			delay:            shortDelay,
+			copies:           1,
+			attempts:         []attempt{{block: true}, {status: http.StatusOK}},
+			expectedStatus:   http.StatusOK,
+			expectedCalls:    2,
+			expectedCanceled: 1,
+		},
+		{
+			name: "body rewind error",
+			request: func() *http.Request {
+				req := request(http.MethodGet, true)
+				req.GetBody = func() (io.ReadCloser, error) {
+					return nil, errRewind
+				}
+
+				return req
+			},
+			delay:         longDelay,
+			copies:        1,
+			attempts:      []attempt{{status: http.StatusBadGateway}},
+			expectedErr:   errRewind,
+			expectedCalls: 1,
+		},
+		{
+			name: "not replayable body",
+			request: func() *http.Request {
+				req := request(http.MethodGet, true)
+				req.GetBody = nil
+
+				return req
+			},
+			delay:          shortDelay,
+			copies:         1,
+			attempts:       []attempt{{status: http.StatusBadGateway}},
+			expectedStatus: http.StatusBadGateway,
+			expectedCalls:  1,
+		},
+		{
+			name: "unsafe method",
+			request: func() *http.Request {
+				return request(http.MethodPost, false)
+			},
+			delay:          shortDelay,
+			copies:         1,
+			attempts:       []attempt{{status: http.StatusBadGateway}},
+			expectedStatus: http.StatusBadGateway,
+			expectedCalls:  1,
+		},
+		{
+			name:           "disabled",
+			request:        get,
+			delay:          shortDelay,
+			attempts:       []attempt{{status: http.StatusBadGateway}},
+			expectedStatus: http.StatusBadGateway,
+			expectedCalls:  1,
+		},
+	}
+}
@@ -0,0 +1,59 @@
+package hedge
+
+import (
+	"math"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	minSamples = 10
+	percents   = 100
+)
+
+// latency holds recent responses latencies.
+type latency struct {
+	samples []time.Duration
+	next    int
+	mu      sync.Mutex
+}
+
+// newLatency creates latency tracker which keeps given samples count.
+func newLatency(size int) *latency {
+	return &latency{
+		samples: make([]time.Duration, 0, size),
+	}
+}
+
+// observe adds latency sample replacing the oldest one.
+func (l *latency) observe(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.samples) < cap(l.samples) {
+		l.samples = append(l.samples, d)
+
+		return
+	}
+
+	l.samples[l.next] = d
+	l.next = (l.next + 1) % len(l.samples)
+}
+
+// percentile returns latency percentile. Returns false until enough samples collected.
+func (l *latency) percentile(p float64) (time.Duration, bool) {
+	l.mu.Lock()
+	sorted := slices.Clone(l.samples)
+	l.mu.Unlock()
+
+	if len(sorted) < minSamples {
+		return 0, false
+	}
+
+	slices.Sort(sorted)
+
+	idx := int(math.Ceil(p/percents*float64(len(sorted)))) - 1
+
+	return sorted[min(max(idx, 0), len(sorted)-1)], true
+}
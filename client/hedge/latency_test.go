@@ -0,0 +1,90 @@
+package hedge
+
+import (
+	"time"
+)
+
+const (
+	unexpectedPercentile = "Unexpected percentile"
+	count                = 4
+)
+
+type latencyCase struct {
+	name     string
+	samples  []time.Duration
+	p        float64
+	size     int
+	expected time.Duration
+	ok       bool
+}
+
+func (s *suite) TestLatencyPercentile() {
+	for _, c := range latencyProvider() {
+		s.Run(c.name, func() {
+			l := newLatency(c.size)
+
+			for _, d := range c.samples {
+				l.observe(d)
+			}
+
+			actual, ok := l.percentile(c.p)
+
+			s.Equal(c.ok, ok, unexpectedPercentile)
+			s.Equal(c.expected, actual, unexpectedPercentile)
+		})
+	}
+}
+
+func latencyProvider() []latencyCase {
+	return []latencyCase{
+		{
+			name:    "not enough samples",
+			size:    minSamples,
+			samples: durations(minSamples - 1),
+			p:       50,
+		},
+		{
+			name:     "median",
+			size:     minSamples,
+			samples:  durations(minSamples),
+			p:        50,
+			expected: 5 * time.Millisecond,
+			ok:       true,
+		},
+		{
+			name:     "95th percentile",
+			size:     minSamples * 2,
+			samples:  durations(minSamples * 2),
+			p:        95,
+			expected: 19 * time.Millisecond,
+			ok:       true,
+		},
+		{
+			name:     "oldest samples replaced",
+			size:     minSamples,
+			samples:  durations(minSamples + count),
+			p:        0,
+			expected: 5 * time.Millisecond,
+			ok:       true,
+		},
+		{
+			name:     "overflow",
+			size:     minSamples,
+			samples:  durations(minSamples),
+			p:        200,
+			expected: 10 * time.Millisecond,
+			ok:       true,
+		},
+	}
+}
+
+// durations returns samples from 1ms to n ms.
+func durations(n int) []time.Duration {
+	res := make([]time.Duration, 0, n)
+
+	for i := range n {
+		res = append(res, time.Duration(i+1)*time.Millisecond)
+	}
+
+	return res
+}
@@ -0,0 +1,47 @@
+package hedge
+
+import (
+	"io"
+	"net/http"
+)
+
+// releaseBody calls release function after response body closing.
+type releaseBody struct {
+	io.ReadCloser
+
+	release func()
+}
+
+// Close [io.Closer] implementation.
+func (b *releaseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+
+	return err
+}
+
+// finish binds release of request copy resources to response body closing.
+func finish(res *http.Response, err error, release func()) (*http.Response, error) {
+	if res == nil || res.Body == nil {
+		release()
+
+		return res, err
+	}
+
+	res.Body = &releaseBody{
+		ReadCloser: res.Body,
+		release:    release,
+	}
+
+	return res, err
+}
+
+// discard drains up to limit bytes of response body and closes it, so connection can be reused.
+func discard(res *http.Response, limit int64) {
+	if res == nil || res.Body == nil {
+		return
+	}
+
+	_, _ = io.CopyN(io.Discard, res.Body, limit)
+	_ = res.Body.Close()
+}
@@ -0,0 +1,131 @@
+package ratelimit
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/nafigator/http/headers"
+)
+
+// Values of X-Ratelimit-Reset above this threshold treated as Unix timestamp instead of delta-seconds.
+const epochThreshold = 1_000_000_000
+
+// bucket holds rate limit state of single host. Local limit is a token bucket. Server limit spreads requests evenly
+// until server quota reset.
+type bucket struct {
+	last     time.Time
+	resetAt  time.Time
+	next     time.Time
+	tokens   float64
+	interval time.Duration
+	mu       sync.Mutex
+}
+
+// newBucket creates full bucket.
+func newBucket(burst float64, now time.Time) *bucket {
+	return &bucket{
+		last:   now,
+		tokens: burst,
+	}
+}
+
+// reserve takes request slot and returns pause before request. Returned function cancels reservation.
+func (b *bucket) reserve(now time.Time, rate, burst float64) (time.Duration, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var wait time.Duration
+
+	if rate > 0 {
+		b.tokens = min(burst, b.tokens+now.Sub(b.last).Seconds()*rate)
+		b.last = now
+		b.tokens--
+
+		if b.tokens < 0 {
+			wait = time.Duration(-b.tokens / rate * float64(time.Second))
+		}
+	}
+
+	prev := b.next
+
+	if now.Before(b.resetAt) {
+		start := later(now.Add(wait), b.next)
+		wait = start.Sub(now)
+		b.next = start.Add(b.interval)
+	}
+
+	reserved := b.next
+
+	return wait, func() {
+		b.cancel(burst, prev, reserved)
+	}
+}
+
+// cancel returns reserved slot.
+func (b *bucket) cancel(burst float64, prev, reserved time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens = min(burst, b.tokens+1)
+
+	if b.next.Equal(reserved) {
+		b.next = prev
+	}
+}
+
+// update adapts server limit by X-Ratelimit-Remaining or X-Ratelimit-Limit and X-Ratelimit-Reset response headers.
+// Requests are blocked until reset when there are no remaining requests.
+func (b *bucket) update(h http.Header, now time.Time) {
+	reset, ok := resetTime(h.Get(headers.XRatelimitReset), now)
+	if !ok || !reset.After(now) {
+		return
+	}
+
+	n, err := strconv.ParseInt(h.Get(headers.XRatelimitRemaining), 10, 64)
+	if err != nil {
+		n, err = strconv.ParseInt(h.Get(headers.XRatelimitLimit), 10, 64)
+	}
+
+	if err != nil || n < 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.resetAt = reset
+
+	if n == 0 {
+		b.next = later(b.next, reset)
+		b.interval = 0
+
+		return
+	}
+
+	b.interval = reset.Sub(now) / time.Duration(n)
+}
+
+// resetTime parses X-Ratelimit-Reset value. It may be delta-seconds or Unix timestamp in seconds.
+func resetTime(v string, now time.Time) (time.Time, bool) {
+	s, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || s < 0 {
+		return time.Time{}, false
+	}
+
+	if s > epochThreshold {
+		return time.Unix(s, 0), true
+	}
+
+	return now.Add(time.Duration(s) * time.Second), true
+}
+
+// later returns the latest of times.
+func later(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,167 @@
+package ratelimit
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/nafigator/http/headers"
+)
+
+const (
+	unexpectedWait = "Unexpected wait"
+	rate           = 10
+	burst          = 2
+)
+
+type updateCase struct {
+	name     string
+	limit    string
+	remain   string
+	reset    string
+	expected []time.Duration
+}
+
+func (s *suite) TestReserve() {
+	now := time.Unix(0, 0)
+	b := newBucket(burst, now)
+
+	d, _ := b.reserve(now, rate, burst)
+	s.Equal(time.Duration(0), d, unexpectedWait)
+
+	d, _ = b.reserve(now, rate, burst)
+	s.Equal(time.Duration(0), d, unexpectedWait)
+
+	d, cancel := b.reserve(now, rate, burst)
+	s.Equal(100*time.Millisecond, d, unexpectedWait)
+
+	cancel()
+
+	d, _ = b.reserve(now.Add(50*time.Millisecond), rate, burst)
+	s.Equal(50*time.Millisecond, d, unexpectedWait)
+
+	d, _ = b.reserve(now.Add(time.Hour), rate, burst)
+	s.Equal(time.Duration(0), d, unexpectedWait)
+}
+
+func (s *suite) TestReserveCancel() {
+	now := time.Unix(0, 0)
+	b := newBucket(0, now)
+
+	b.update(header("", "0", "10"), now)
+
+	first, cancelFirst := b.reserve(now, 0, 0)
+	second, cancelSecond := b.reserve(now, 0, 0)
+
+	s.Equal(10*time.Second, first, unexpectedWait)
+	s.Equal(10*time.Second, second, unexpectedWait)
+
+	cancelFirst()
+	cancelSecond()
+
+	b.update(header("", "2", "10"), now)
+
+	d, cancel := b.reserve(now, 0, 0)
+	s.Equal(10*time.Second, d, unexpectedWait)
+
+	cancel()
+
+	d, _ = b.reserve(now, 0, 0)
+	s.Equal(10*time.Second, d, unexpectedWait)
+}
+
+func (s *suite) TestUpdate() {
+	for _, c := range updateProvider() {
+		s.Run(c.name, func() {
+			now := time.Unix(2*epochThreshold, 0)
+			b := newBucket(0, now)
+
+			b.update(header(c.limit, c.remain, c.reset), now)
+
+			actual := make([]time.Duration, 0, len(c.expected))
+
+			for range c.expected {
+				d, _ := b.reserve(now, 0, 0)
+				actual = append(actual, d)
+			}
+
+			s.Equal(c.expected, actual, unexpectedWait)
+		})
+	}
+}
+
+func header(limit, remain, reset string) http.Header {
+	h := http.Header{}
+
+	if limit != "" {
+		h.Set(headers.XRatelimitLimit, limit)
+	}
+
+	if remain != "" {
+		h.Set(headers.XRatelimitRemaining, remain)
+	}
+
+	if reset != "" {
+		h.Set(headers.XRatelimitReset, reset)
+	}
+
+	return h
+}
+
+func updateProvider() []updateCase {
+	return []updateCase{
+		{
+			name:     "without headers",
+			expected: []time.Duration{0, 0},
+		},
+		{
+			name:     "exhausted quota",
+			remain:   "0",
+			reset:    "30",
+			expected: []time.Duration{30 * time.Second, 30 * time.Second},
+		},
+		{
+			name:     "remaining requests",
+			limit:    "100",
+			remain:   "4",
+			reset:    "2",
+			expected: []time.Duration{0, 500 * time.Millisecond, time.Second, 1500 * time.Millisecond},
+		},
+		{
+			name:     "limit without remaining",
+			limit:    "2",
+			reset:    "2",
+			expected: []time.Duration{0, time.Second},
+		},
+		{
+			name:     "epoch reset",
+			remain:   "0",
+			reset:    strconv.Itoa(2*epochThreshold + 5),
+			expected: []time.Duration{5 * time.Second},
+		},
+		{
+			name:     "past reset",
+			remain:   "0",
+			reset:    strconv.Itoa(2*epochThreshold - 5),
+			expected: []time.Duration{0},
+		},
+		{
+			name:     "invalid reset",
+			remain:   "0",
+			reset:    "-1",
+			expected: []time.Duration{0},
+		},
+		{
+			name:     "invalid remaining",
+			remain:   "many",
+			reset:    "10",
+			expected: []time.Duration{0},
+		},
+		{
+			name:     "negative remaining",
+			remain:   "-1",
+			reset:    "10",
+			expected: []time.Duration{0},
+		},
+	}
+}
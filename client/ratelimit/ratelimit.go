@@ -0,0 +1,144 @@
+// Package ratelimit provides client-side rate limiting for [http.Client].
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrLimited returned in fail-fast mode for requests which exceed rate limit.
+var ErrLimited = errors.New("rate limit exceeded")
+
+type HTTPLimiter struct {
+	next     http.RoundTripper
+	key      func(req *http.Request) string
+	now      func() time.Time
+	sleep    func(ctx context.Context, d time.Duration) error
+	buckets  map[string]*bucket
+	rate     float64
+	burst    float64
+	mu       sync.Mutex
+	failFast bool
+	adaptive bool
+}
+
+// New creates http rate limiter instance. By default, there is no local rate limit and requests are slowed down
+// by X-Ratelimit-* response headers only.
+func New(
+	next http.RoundTripper,
+) *HTTPLimiter {
+	return &HTTPLimiter{
+		next:     next,
+		key:      Host,
+		now:      time.Now,
+		sleep:    sleep,
+		buckets:  make(map[string]*bucket),
+		adaptive: true,
+	}
+}
+
+// RoundTrip [http.RoundTripper] implementation.
+func (l *HTTPLimiter) RoundTrip(req *http.Request) (*http.Response, error) {
+	key := l.key(req)
+	b := l.bucket(key)
+
+	if err := l.wait(req.Context(), b, key); err != nil {
+		return nil, err
+	}
+
+	res, err := l.next.RoundTrip(req)
+	if l.adaptive && res != nil {
+		b.update(res.Header, l.now())
+	}
+
+	return res, err
+}
+
+// Host returns request host with port. It is default rate limit key, so every host has own limit.
+func Host(req *http.Request) string {
+	return req.URL.Host
+}
+
+// WithRate sets local limit of requests per second with burst size. Zero rate disables local limit.
+func (l *HTTPLimiter) WithRate(rate float64, burst int) *HTTPLimiter {
+	l.rate = rate
+	l.burst = float64(max(burst, 1))
+
+	return l
+}
+
+// WithFailFast sets mode for exceeded limit. Requests are rejected with [ErrLimited] instead of waiting.
+func (l *HTTPLimiter) WithFailFast(failFast bool) *HTTPLimiter {
+	l.failFast = failFast
+
+	return l
+}
+
+// WithAdaptive enables or disables slowing down by X-Ratelimit-Limit, X-Ratelimit-Remaining and X-Ratelimit-Reset
+// response headers.
+func (l *HTTPLimiter) WithAdaptive(adaptive bool) *HTTPLimiter {
+	l.adaptive = adaptive
+
+	return l
+}
+
+// WithKey sets function which groups requests by limits. See [Host].
+func (l *HTTPLimiter) WithKey(f func(req *http.Request) string) *HTTPLimiter {
+	l.key = f
+
+	return l
+}
+
+// bucket returns bucket for key.
+func (l *HTTPLimiter) bucket(key string) *bucket {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = newBucket(l.burst, l.now())
+		l.buckets[key] = b
+	}
+
+	return b
+}
+
+// wait reserves request slot and waits for it. Reservation is canceled when request is rejected or its context is
+// done.
+func (l *HTTPLimiter) wait(ctx context.Context, b *bucket, key string) error {
+	d, cancel := b.reserve(l.now(), l.rate, l.burst)
+	if d <= 0 {
+		return nil
+	}
+
+	if l.failFast {
+		cancel()
+
+		return fmt.Errorf("%w: %s: retry in %s", ErrLimited, key, d)
+	}
+
+	if err := l.sleep(ctx, d); err != nil {
+		cancel()
+
+		return err
+	}
+
+	return nil
+}
+
+// sleep pauses until duration elapsed or context is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
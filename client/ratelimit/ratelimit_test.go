@@ -0,0 +1,167 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/nafigator/http/headers"
+)
+
+const (
+	unexpectedError    = "Unexpected error"
+	unexpectedResponse = "Unexpected response"
+	unexpectedPauses   = "Unexpected pauses"
+	firstHost          = "first.localhost"
+	secondHost         = "second.localhost"
+)
+
+type roundTripCase struct {
+	expectedErr    error
+	limiter        func(l *HTTPLimiter) *HTTPLimiter
+	name           string
+	hosts          []string
+	remaining      string
+	expectedPauses []time.Duration
+	expectedCalls  int
+}
+
+func (s *suite) TestRoundTrip() {
+	for _, c := range roundTripProvider() {
+		s.Run(c.name, func() {
+			ctrl := gomock.NewController(s.T())
+			next := NewMockRoundTripper(ctrl)
+			now := time.Unix(0, 0)
+
+			var pauses []time.Duration
+
+			l := c.limiter(New(next))
+			l.now = func() time.Time {
+				return now
+			}
+			l.sleep = func(_ context.Context, d time.Duration) error {
+				pauses = append(pauses, d)
+
+				return nil
+			}
+
+			next.EXPECT().
+				RoundTrip(gomock.Any()).
+				DoAndReturn(func(_ *http.Request) (*http.Response, error) {
+					rec := httptest.NewRecorder()
+					rec.Header().Set(headers.XRatelimitRemaining, c.remaining)
+					rec.Header().Set(headers.XRatelimitReset, "60")
+
+					return rec.Result(), nil
+				}).
+				Times(c.expectedCalls)
+
+			var err error
+
+			for _, host := range c.hosts {
+				req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "https://"+host, nil)
+
+				var res *http.Response
+
+				res, err = l.RoundTrip(req)
+				if err == nil {
+					s.Equal(http.StatusOK, res.StatusCode, unexpectedResponse)
+				}
+			}
+
+			s.Require().ErrorIs(err, c.expectedErr, unexpectedError)
+			s.Equal(c.expectedPauses, pauses, unexpectedPauses)
+		})
+	}
+}
+
+func (s *suite) TestWaitCancel() {
+	ctrl := gomock.NewController(s.T())
+	next := NewMockRoundTripper(ctrl)
+
+	l := New(next).WithRate(1, 1)
+
+	next.EXPECT().
+		RoundTrip(gomock.Any()).
+		Return(httptest.NewRecorder().Result(), nil)
+
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "https://"+firstHost, nil)
+
+	res, err := l.RoundTrip(req)
+	s.Require().NoError(err, unexpectedError)
+	s.Equal(http.StatusOK, res.StatusCode, unexpectedResponse)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	res, err = l.RoundTrip(req.WithContext(ctx))
+	s.Require().ErrorIs(err, context.Canceled, unexpectedError)
+	s.Nil(res, unexpectedResponse)
+}
+
+func (s *suite) TestSleep() {
+	s.Require().NoError(sleep(context.Background(), time.Nanosecond), unexpectedError)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	s.Require().ErrorIs(sleep(ctx, time.Hour), context.Canceled, unexpectedError)
+}
+
+func roundTripProvider() []roundTripCase {
+	return []roundTripCase{
+		{
+			name: "local rate",
+			limiter: func(l *HTTPLimiter) *HTTPLimiter {
+				return l.WithRate(rate, burst)
+			},
+			hosts:          []string{firstHost, firstHost, firstHost, firstHost, secondHost},
+			expectedPauses: []time.Duration{100 * time.Millisecond, 200 * time.Millisecond},
+			expectedCalls:  5,
+		},
+		{
+			name: "fail fast",
+			limiter: func(l *HTTPLimiter) *HTTPLimiter {
+				return l.WithRate(rate, burst).
+					WithFailFast(true)
+			},
+			hosts:         []string{firstHost, firstHost, firstHost},
+			expectedErr:   ErrLimited,
+			expectedCalls: 2,
+		},
+		{
+			name: "exhausted server quota",
+			limiter: func(l *HTTPLimiter) *HTTPLimiter {
+				return l
+			},
+			hosts:          []string{firstHost, firstHost, secondHost},
+			remaining:      "0",
+			expectedPauses: []time.Duration{time.Minute},
+			expectedCalls:  3,
+		},
+		{
+			name: "not adaptive",
+			limiter: func(l *HTTPLimiter) *HTTPLimiter {
+				return l.WithAdaptive(false)
+			},
+			hosts:         []string{firstHost, firstHost},
+			remaining:     "0",
+			expectedCalls: 2,
+		},
+		{
+			name: "custom key",
+			limiter: func(l *HTTPLimiter) *HTTPLimiter {
+				return l.WithKey(func(_ *http.Request) string {
+					return "all"
+				})
+			},
+			hosts:          []string{firstHost, secondHost},
+			remaining:      "0",
+			expectedPauses: []time.Duration{time.Minute},
+			expectedCalls:  2,
+		},
+	}
+}
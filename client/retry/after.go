@@ -0,0 +1,56 @@
+package retry
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/nafigator/http/headers"
+)
+
+// Values of X-Ratelimit-Reset above this threshold treated as Unix timestamp instead of delta-seconds.
+const epochThreshold = 1_000_000_000
+
+// serverPause returns pause requested by server with Retry-After or X-Ratelimit-Reset response headers.
+// Retry-After value may be delta-seconds or HTTP-date. X-Ratelimit-Reset value may be delta-seconds or Unix
+// timestamp in seconds.
+func serverPause(res *http.Response, now time.Time) (time.Duration, bool) {
+	if res == nil {
+		return 0, false
+	}
+
+	if v := res.Header.Get(headers.RetryAfter); v != "" {
+		if s, err := strconv.ParseInt(v, 10, 64); err == nil && s >= 0 {
+			return seconds(s), true
+		}
+
+		if t, err := http.ParseTime(v); err == nil {
+			return max(t.Sub(now), 0), true
+		}
+	}
+
+	if v := res.Header.Get(headers.XRatelimitReset); v != "" {
+		s, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || s < 0 {
+			return 0, false
+		}
+
+		if s > epochThreshold {
+			return max(time.Unix(s, 0).Sub(now), 0), true
+		}
+
+		return seconds(s), true
+	}
+
+	return 0, false
+}
+
+// seconds converts seconds count to duration without overflow.
+func seconds(s int64) time.Duration {
+	if s > math.MaxInt64/int64(time.Second) {
+		return math.MaxInt64
+	}
+
+	return time.Duration(s) * time.Second
+}
@@ -0,0 +1,169 @@
+package retry
+
+import (
+	"bytes"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/nafigator/http/headers"
+)
+
+const unexpectedFound = "Unexpected server pause presence"
+
+type serverPauseCase struct {
+	response      *http.Response
+	name          string
+	expected      time.Duration
+	expectedFound bool
+}
+
+type retryAfterCase struct {
+	name          string
+	value         string
+	maxRetryAfter time.Duration
+	expected      []recordedPause
+}
+
+func (s *suite) TestServerPause() {
+	now := time.Date(2025, time.January, 8, 9, 18, 29, 0, time.UTC)
+
+	for _, c := range serverPauseProvider(now) {
+		s.Run(c.name, func() {
+			actual, found := serverPause(c.response, now)
+
+			s.Equal(c.expectedFound, found, unexpectedFound)
+			s.Equal(c.expected, actual, unexpectedPause)
+		})
+	}
+}
+
+func (s *suite) TestRetryAfter() {
+	for _, c := range retryAfterProvider() {
+		s.Run(c.name, func() {
+			ctrl := gomock.NewController(s.T())
+			next := NewMockRoundTripper(ctrl)
+			b := &recordBackoff{}
+
+			r := New(next).
+				WithBackoff(b).
+				WithMaxRetryAfter(c.maxRetryAfter).
+				WithLimit(2)
+
+			next.EXPECT().
+				RoundTrip(gomock.Any()).
+				DoAndReturn(func(_ *http.Request) (*http.Response, error) {
+					rec := httptest.NewRecorder()
+					rec.Header().Set(headers.RetryAfter, c.value)
+					rec.WriteHeader(http.StatusTooManyRequests)
+
+					return rec.Result(), nil
+				}).
+				Times(2)
+
+			request, _ := http.NewRequest(http.MethodGet, URL, bytes.NewBufferString(""))
+			start := time.Now()
+			res, err := r.RoundTrip(request)
+
+			s.Require().NoError(err, unexpectedError)
+			s.Equal(http.StatusTooManyRequests, res.StatusCode, unexpectedResponse)
+			s.Equal(c.expected, b.pauses, unexpectedPause)
+			s.Less(time.Since(start), time.Second, unexpectedPause)
+		})
+	}
+}
+
+func serverPauseProvider(now time.Time) []serverPauseCase {
+	response := func(name, value string) *http.Response {
+		rec := httptest.NewRecorder()
+		rec.Header().Set(name, value)
+		rec.WriteHeader(http.StatusServiceUnavailable)
+
+		return rec.Result()
+	}
+
+	return []serverPauseCase{
+		{
+			name: "nil response",
+		},
+		{
+			name:     "without headers",
+			response: httptest.NewRecorder().Result(),
+		},
+		{
+			name:          "retry after seconds",
+			response:      response(headers.RetryAfter, "120"),
+			expected:      2 * time.Minute,
+			expectedFound: true,
+		},
+		{
+			name:          "retry after seconds overflow",
+			response:      response(headers.RetryAfter, strconv.FormatInt(math.MaxInt64, 10)),
+			expected:      math.MaxInt64,
+			expectedFound: true,
+		},
+		{
+			name:          "retry after date",
+			response:      response(headers.RetryAfter, now.Add(time.Minute).Format(http.TimeFormat)),
+			expected:      time.Minute,
+			expectedFound: true,
+		},
+		{
+			name:          "retry after date in past",
+			response:      response(headers.RetryAfter, now.Add(-time.Minute).Format(http.TimeFormat)),
+			expected:      0,
+			expectedFound: true,
+		},
+		{
+			name:     "retry after invalid",
+			response: response(headers.RetryAfter, "soon"),
+		},
+		{
+			name:          "ratelimit reset seconds",
+			response:      response(headers.XRatelimitReset, "30"),
+			expected:      30 * time.Second,
+			expectedFound: true,
+		},
+		{
+			name:          "ratelimit reset timestamp",
+			response:      response(headers.XRatelimitReset, strconv.FormatInt(now.Add(time.Hour).Unix(), 10)),
+			expected:      time.Hour,
+			expectedFound: true,
+		},
+		{
+			name:     "ratelimit reset invalid",
+			response: response(headers.XRatelimitReset, "-1"),
+		},
+	}
+}
+
+func retryAfterProvider() []retryAfterCase {
+	return []retryAfterCase{
+		{
+			name:          "server pause",
+			value:         "0",
+			maxRetryAfter: time.Minute,
+		},
+		{
+			name:          "server pause limited",
+			value:         "120",
+			maxRetryAfter: time.Nanosecond,
+		},
+		{
+			name:          "server pause disabled",
+			value:         "0",
+			maxRetryAfter: 0,
+			expected:      []recordedPause{{attempt: 1, last: 0}},
+		},
+		{
+			name:          "server pause invalid",
+			value:         "soon",
+			maxRetryAfter: time.Minute,
+			expected:      []recordedPause{{attempt: 1, last: 0}},
+		},
+	}
+}
@@ -0,0 +1,152 @@
+package retry
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+const (
+	defaultMultiplier           = 2
+	defaultDecorrelatedMultiple = 3
+)
+
+// Backoff calculates pause before next request attempt.
+type Backoff interface {
+	// Pause returns pause before retry. Attempt starts from 1 for the first retry, last is a previous pause
+	// (zero before the first retry).
+	Pause(attempt uint, last time.Duration) time.Duration
+}
+
+// Constant backoff makes equal pauses between attempts.
+type Constant struct {
+	pause time.Duration
+}
+
+// Linear backoff increases pause by base on each attempt.
+type Linear struct {
+	base time.Duration
+	ceil time.Duration
+}
+
+// Exponential backoff multiplies pause by multiplier on each attempt.
+type Exponential struct {
+	base       time.Duration
+	ceil       time.Duration
+	multiplier float64
+}
+
+// FullJitter backoff makes random pause between zero and exponential backoff value.
+type FullJitter struct {
+	exp *Exponential
+}
+
+// DecorrelatedJitter backoff makes random pause between base and previous pause multiplied by multiplier.
+type DecorrelatedJitter struct {
+	base       time.Duration
+	ceil       time.Duration
+	multiplier float64
+}
+
+// NewConstant creates constant backoff instance.
+func NewConstant(pause time.Duration) *Constant {
+	return &Constant{
+		pause: pause,
+	}
+}
+
+// Pause [Backoff] implementation.
+func (b *Constant) Pause(_ uint, _ time.Duration) time.Duration {
+	return b.pause
+}
+
+// NewLinear creates linear backoff instance. Zero ceil means no upper limit for pause.
+func NewLinear(base, ceil time.Duration) *Linear {
+	return &Linear{
+		base: base,
+		ceil: ceil,
+	}
+}
+
+// Pause [Backoff] implementation.
+func (b *Linear) Pause(attempt uint, _ time.Duration) time.Duration {
+	return limit(float64(b.base)*float64(attempt), b.ceil)
+}
+
+// NewExponential creates exponential backoff instance. Zero ceil means no upper limit for pause. Multiplier less
+// or equal to 1 replaced by 2.
+func NewExponential(base, ceil time.Duration, multiplier float64) *Exponential {
+	if multiplier <= 1 {
+		multiplier = defaultMultiplier
+	}
+
+	return &Exponential{
+		base:       base,
+		ceil:       ceil,
+		multiplier: multiplier,
+	}
+}
+
+// Pause [Backoff] implementation.
+func (b *Exponential) Pause(attempt uint, _ time.Duration) time.Duration {
+	if attempt == 0 {
+		return 0
+	}
+
+	return limit(float64(b.base)*math.Pow(b.multiplier, float64(attempt-1)), b.ceil)
+}
+
+// NewFullJitter creates full jitter backoff instance. Arguments are the same as for [NewExponential].
+func NewFullJitter(base, ceil time.Duration, multiplier float64) *FullJitter {
+	return &FullJitter{
+		exp: NewExponential(base, ceil, multiplier),
+	}
+}
+
+// Pause [Backoff] implementation.
+func (b *FullJitter) Pause(attempt uint, last time.Duration) time.Duration {
+	return random(0, b.exp.Pause(attempt, last))
+}
+
+// NewDecorrelatedJitter creates decorrelated jitter backoff instance. Zero ceil means no upper limit for pause.
+// Multiplier less or equal to 1 replaced by 3.
+func NewDecorrelatedJitter(base, ceil time.Duration, multiplier float64) *DecorrelatedJitter {
+	if multiplier <= 1 {
+		multiplier = defaultDecorrelatedMultiple
+	}
+
+	return &DecorrelatedJitter{
+		base:       base,
+		ceil:       ceil,
+		multiplier: multiplier,
+	}
+}
+
+// Pause [Backoff] implementation.
+func (b *DecorrelatedJitter) Pause(_ uint, last time.Duration) time.Duration {
+	last = max(last, b.base)
+
+	return limit(float64(random(b.base, limit(float64(last)*b.multiplier, 0))), b.ceil)
+}
+
+// limit converts pause to duration with respect of upper bound. Zero ceil means only overflow check.
+func limit(pause float64, ceil time.Duration) time.Duration {
+	if ceil > 0 && pause > float64(ceil) {
+		return ceil
+	}
+
+	if pause >= math.MaxInt64 {
+		return math.MaxInt64
+	}
+
+	return time.Duration(pause)
+}
+
+// random returns pseudo-random duration in range [low, high).
+func random(low, high time.Duration) time.Duration {
+	if high <= low {
+		return low
+	}
+
+	return low + rand.N(high-low) //nolint:gosec // Jitter doesn't require crypto random
+}
@@ -0,0 +1,213 @@
+package retry
+
+import (
+	"bytes"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	unexpectedPause = "Unexpected pause"
+	jitterRuns      = 100
+)
+
+type pauseCase struct {
+	backoff  Backoff
+	name     string
+	attempt  uint
+	last     time.Duration
+	expected time.Duration
+}
+
+type jitterCase struct {
+	backoff Backoff
+	name    string
+	attempt uint
+	last    time.Duration
+	low     time.Duration
+	high    time.Duration
+}
+
+type recordedPause struct {
+	attempt uint
+	last    time.Duration
+}
+
+type recordBackoff struct {
+	pauses []recordedPause
+}
+
+func (b *recordBackoff) Pause(attempt uint, last time.Duration) time.Duration {
+	b.pauses = append(b.pauses, recordedPause{attempt: attempt, last: last})
+
+	return time.Duration(attempt) * time.Nanosecond
+}
+
+func (s *suite) TestBackoffPause() {
+	for _, c := range pauseProvider() {
+		s.Run(c.name, func() {
+			s.Equal(c.expected, c.backoff.Pause(c.attempt, c.last), unexpectedPause)
+		})
+	}
+}
+
+func (s *suite) TestBackoffJitter() {
+	for _, c := range jitterProvider() {
+		s.Run(c.name, func() {
+			for range jitterRuns {
+				actual := c.backoff.Pause(c.attempt, c.last)
+
+				s.GreaterOrEqual(actual, c.low, unexpectedPause)
+				s.LessOrEqual(actual, c.high, unexpectedPause)
+			}
+		})
+	}
+}
+
+func (s *suite) TestWithBackoff() {
+	ctrl := gomock.NewController(s.T())
+	next := NewMockRoundTripper(ctrl)
+	b := &recordBackoff{}
+
+	r := New(next).
+		WithBackoff(b).
+		WithLimit(count)
+
+	response502 := httptest.NewRecorder()
+	response502.Code = http.StatusBadGateway
+
+	next.EXPECT().
+		RoundTrip(gomock.Any()).
+		DoAndReturn(func(_ *http.Request) (*http.Response, error) {
+			return response502.Result(), nil
+		}).
+		Times(count)
+
+	request, _ := http.NewRequest(http.MethodGet, URL, bytes.NewBufferString(""))
+	res, err := r.RoundTrip(request)
+
+	s.Require().NoError(err, unexpectedError)
+	s.Equal(http.StatusBadGateway, res.StatusCode, unexpectedResponse)
+	s.Equal([]recordedPause{
+		{attempt: 1, last: 0},
+		{attempt: 2, last: time.Nanosecond},
+		{attempt: 3, last: 2 * time.Nanosecond},
+	}, b.pauses, unexpectedPause)
+}
+
+func pauseProvider() []pauseCase {
+	return []pauseCase{
+		{
+			name:     "constant",
+			backoff:  NewConstant(time.Second),
+			attempt:  5,
+			expected: time.Second,
+		},
+		{
+			name:     "linear",
+			backoff:  NewLinear(time.Second, 0),
+			attempt:  3,
+			expected: 3 * time.Second,
+		},
+		{
+			name:     "linear with ceil",
+			backoff:  NewLinear(time.Second, 2*time.Second),
+			attempt:  3,
+			expected: 2 * time.Second,
+		},
+		{
+			name:     "exponential first retry",
+			backoff:  NewExponential(time.Second, 0, 3),
+			attempt:  1,
+			expected: time.Second,
+		},
+		{
+			name:     "exponential",
+			backoff:  NewExponential(time.Second, 0, 3),
+			attempt:  3,
+			expected: 9 * time.Second,
+		},
+		{
+			name:     "exponential with default multiplier",
+			backoff:  NewExponential(time.Second, 0, 0),
+			attempt:  4,
+			expected: 8 * time.Second,
+		},
+		{
+			name:     "exponential with ceil",
+			backoff:  NewExponential(time.Second, 5*time.Second, 2),
+			attempt:  4,
+			expected: 5 * time.Second,
+		},
+		{
+			name:     "exponential overflow",
+			backoff:  NewExponential(time.Second, 0, 2),
+			attempt:  math.MaxUint32,
+			expected: math.MaxInt64,
+		},
+		{
+			name:     "exponential zero attempt",
+			backoff:  NewExponential(time.Second, 0, 2),
+			attempt:  0,
+			expected: 0,
+		},
+		{
+			name:     "full jitter with zero base",
+			backoff:  NewFullJitter(0, time.Second, 2),
+			attempt:  3,
+			expected: 0,
+		},
+		{
+			name:     "decorrelated jitter with zero base",
+			backoff:  NewDecorrelatedJitter(0, time.Second, 3),
+			attempt:  2,
+			expected: 0,
+		},
+	}
+}
+
+func jitterProvider() []jitterCase {
+	return []jitterCase{
+		{
+			name:    "full jitter",
+			backoff: NewFullJitter(time.Second, 0, 2),
+			attempt: 3,
+			low:     0,
+			high:    4 * time.Second,
+		},
+		{
+			name:    "full jitter with ceil",
+			backoff: NewFullJitter(time.Second, 2*time.Second, 2),
+			attempt: 10,
+			low:     0,
+			high:    2 * time.Second,
+		},
+		{
+			name:    "decorrelated jitter first retry",
+			backoff: NewDecorrelatedJitter(time.Second, 0, 0),
+			attempt: 1,
+			low:     time.Second,
+			high:    3 * time.Second,
+		},
+		{
+			name:    "decorrelated jitter",
+			backoff: NewDecorrelatedJitter(time.Second, 0, 2),
+			attempt: 4,
+			last:    5 * time.Second,
+			low:     time.Second,
+			high:    10 * time.Second,
+		},
+		{
+			name:    "decorrelated jitter with ceil",
+			backoff: NewDecorrelatedJitter(time.Second, 3*time.Second, 3),
+			attempt: 4,
+			last:    3 * time.Second,
+			low:     time.Second,
+			high:    3 * time.Second,
+		},
+	}
+}
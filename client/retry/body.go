@@ -0,0 +1,104 @@
+package retry
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ErrBodyNotReplayable returned when request requires retry, but its body can't be sent again.
+var ErrBodyNotReplayable = errors.New("request body can't be replayed")
+
+// body provides request body for every attempt.
+type body struct {
+	first   io.ReadCloser
+	getBody func() (io.ReadCloser, error)
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// newBody prepares request body for replay. It uses [http.Request.GetBody] when it is set. Otherwise, body up to
+// limit bytes is buffered in memory. Larger bodies and bodies with disabled buffering are sent only once.
+func newBody(req *http.Request, limit int64) (*body, error) {
+	switch {
+	case req.Body == nil || req.Body == http.NoBody:
+		return &body{
+			getBody: func() (io.ReadCloser, error) {
+				return req.Body, nil
+			},
+		}, nil
+	case req.GetBody != nil:
+		return &body{
+			first:   req.Body,
+			getBody: req.GetBody,
+		}, nil
+	case limit <= 0:
+		return &body{
+			first: req.Body,
+		}, nil
+	}
+
+	buf, err := io.ReadAll(io.LimitReader(req.Body, limit+1))
+	if err != nil {
+		_ = req.Body.Close()
+
+		return nil, fmt.Errorf("request body buffering: %w", err)
+	}
+
+	if int64(len(buf)) > limit {
+		return &body{
+			first: readCloser{
+				Reader: io.MultiReader(bytes.NewReader(buf), req.Body),
+				Closer: req.Body,
+			},
+		}, nil
+	}
+
+	_ = req.Body.Close()
+
+	return &body{
+		getBody: func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(buf)), nil
+		},
+	}, nil
+}
+
+// next returns body for next attempt.
+func (b *body) next() (io.ReadCloser, error) {
+	if b.first != nil {
+		first := b.first
+		b.first = nil
+
+		return first, nil
+	}
+
+	rc, err := b.getBody()
+	if err != nil {
+		return nil, fmt.Errorf("request body rewind: %w", err)
+	}
+
+	return rc, nil
+}
+
+// replayable reports whether body can be sent again.
+func (b *body) replayable() bool {
+	return b.getBody != nil
+}
+
+// notReplayable returns error for request which requires retry, but can't be retried.
+func notReplayable(res *http.Response, err error) error {
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrBodyNotReplayable, err)
+	}
+
+	if res != nil {
+		return fmt.Errorf("%w: response status %d", ErrBodyNotReplayable, res.StatusCode)
+	}
+
+	return ErrBodyNotReplayable
+}
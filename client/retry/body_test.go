@@ -0,0 +1,188 @@
+package retry
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	payload          = `{"name":"Boris", "age": 20}`
+	unexpectedBodies = "Unexpected request bodies"
+)
+
+type bodyCase struct {
+	expectedErr    error
+	transportErr   error
+	request        func() *http.Request
+	validator      func(r *http.Response, err error) bool
+	name           string
+	expectedBodies []string
+	bufferLimit    int64
+	expectedCalls  int
+}
+
+type failReader struct {
+	err error
+}
+
+func (r failReader) Read(_ []byte) (int, error) {
+	return 0, r.err
+}
+
+func (s *suite) TestBodyReplay() {
+	for _, c := range bodyProvider() {
+		s.Run(c.name, func() {
+			ctrl := gomock.NewController(s.T())
+			next := NewMockRoundTripper(ctrl)
+
+			r := New(next).
+				WithPause(0).
+				WithLimit(2).
+				WithBodyBuffer(c.bufferLimit)
+
+			if c.validator != nil {
+				r.WithRespValidator(c.validator)
+			}
+
+			var actualBodies []string
+
+			next.EXPECT().
+				RoundTrip(gomock.Any()).
+				DoAndReturn(func(req *http.Request) (*http.Response, error) {
+					if req.Body != nil {
+						b, _ := io.ReadAll(req.Body)
+						_ = req.Body.Close()
+						actualBodies = append(actualBodies, string(b))
+					}
+
+					if c.transportErr != nil {
+						return nil, c.transportErr
+					}
+
+					rec := httptest.NewRecorder()
+					rec.WriteHeader(http.StatusBadGateway)
+
+					return rec.Result(), nil
+				}).
+				Times(c.expectedCalls)
+
+			res, err := r.RoundTrip(c.request())
+
+			s.Equal(c.expectedBodies, actualBodies, unexpectedBodies)
+
+			if c.expectedErr == nil {
+				s.Require().NoError(err, unexpectedError)
+				s.Equal(http.StatusBadGateway, res.StatusCode, unexpectedResponse)
+
+				return
+			}
+
+			s.Require().ErrorIs(err, c.expectedErr, unexpectedError)
+			s.Nil(res, unexpectedResponse)
+
+			if c.transportErr != nil {
+				s.Require().ErrorIs(err, c.transportErr, unexpectedError)
+			}
+		})
+	}
+}
+
+func (s *suite) TestNotReplayable() {
+	s.Require().ErrorIs(notReplayable(nil, nil), ErrBodyNotReplayable, unexpectedError)
+}
+
+func bodyProvider() []bodyCase {
+	transportErr := errors.New("connection reset")
+	getBodyErr := errors.New("get body error")
+	readErr := errors.New("read error")
+
+	return []bodyCase{
+		{
+			name: "body with GetBody",
+			request: func() *http.Request {
+				req, _ := http.NewRequest(http.MethodPost, URL, bytes.NewBufferString(payload))
+
+				return req
+			},
+			expectedBodies: []string{payload, payload},
+			expectedCalls:  2,
+		},
+		{
+			name: "without body",
+			request: func() *http.Request {
+				req, _ := http.NewRequest(http.MethodGet, URL, nil)
+
+				return req
+			},
+			expectedCalls: 2,
+		},
+		{
+			name: "buffered body",
+			request: func() *http.Request {
+				req, _ := http.NewRequest(http.MethodPost, URL, io.NopCloser(strings.NewReader(payload)))
+
+				return req
+			},
+			bufferLimit:    int64(len(payload)),
+			expectedBodies: []string{payload, payload},
+			expectedCalls:  2,
+		},
+		{
+			name: "body larger than buffer",
+			request: func() *http.Request {
+				req, _ := http.NewRequest(http.MethodPost, URL, io.NopCloser(strings.NewReader(payload)))
+
+				return req
+			},
+			bufferLimit:    int64(len(payload) - 1),
+			expectedBodies: []string{payload},
+			expectedCalls:  1,
+			expectedErr:    ErrBodyNotReplayable,
+		},
+		{
+			name: "body without buffer and transport error",
+			request: func() *http.Request {
+				req, _ := http.NewRequest(http.MethodPost, URL, io.NopCloser(strings.NewReader(payload)))
+
+				return req
+			},
+			validator: func(_ *http.Response, err error) bool {
+				return err == nil
+			},
+			transportErr:   transportErr,
+			expectedBodies: []string{payload},
+			expectedCalls:  1,
+			expectedErr:    ErrBodyNotReplayable,
+		},
+		{
+			name: "buffering error",
+			request: func() *http.Request {
+				req, _ := http.NewRequest(http.MethodPost, URL, io.NopCloser(failReader{err: readErr}))
+
+				return req
+			},
+			bufferLimit: int64(len(payload)),
+			expectedErr: readErr,
+		},
+		{
+			name: "GetBody error",
+			request: func() *http.Request {
+				req, _ := http.NewRequest(http.MethodPost, URL, bytes.NewBufferString(payload))
+				req.GetBody = func() (io.ReadCloser, error) {
+					return nil, getBodyErr
+				}
+
+				return req
+			},
+			expectedBodies: []string{payload},
+			expectedCalls:  1,
+			expectedErr:    getBodyErr,
+		},
+	}
+}
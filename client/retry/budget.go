@@ -0,0 +1,53 @@
+package retry
+
+import "sync"
+
+// Budget is a token bucket which limits retries ratio to successful requests. It is safe for concurrent use and may
+// be shared between many [HTTPRetry] instances. Every successful request deposits ratio tokens, every retry withdraws
+// one token. Retries are denied when bucket has less than one token.
+type Budget struct {
+	mu     sync.Mutex
+	tokens float64
+	burst  float64
+	ratio  float64
+}
+
+// NewBudget creates retry budget instance. Ratio is a tokens count deposited by successful request, e.g. 0.1 allows
+// one retry per ten successful requests. Burst is a maximum and initial tokens count.
+func NewBudget(ratio, burst float64) *Budget {
+	return &Budget{
+		tokens: burst,
+		burst:  burst,
+		ratio:  ratio,
+	}
+}
+
+// Withdraw takes one token for retry. Returns false when budget is exhausted.
+func (b *Budget) Withdraw() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.tokens < 1 {
+		return false
+	}
+
+	b.tokens--
+
+	return true
+}
+
+// Deposit adds tokens for successful request.
+func (b *Budget) Deposit() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens = min(b.tokens+b.ratio, b.burst)
+}
+
+// Tokens returns available tokens count.
+func (b *Budget) Tokens() float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.tokens
+}
@@ -0,0 +1,106 @@
+package retry
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"go.uber.org/mock/gomock"
+)
+
+const unexpectedTokens = "Unexpected budget tokens"
+
+func (s *suite) TestBudget() {
+	b := NewBudget(0.5, 2)
+
+	s.True(b.Withdraw(), unexpectedTokens)
+	s.True(b.Withdraw(), unexpectedTokens)
+	s.False(b.Withdraw(), unexpectedTokens)
+	s.InDelta(0.0, b.Tokens(), 0, unexpectedTokens)
+
+	b.Deposit()
+	s.False(b.Withdraw(), unexpectedTokens)
+
+	b.Deposit()
+	s.True(b.Withdraw(), unexpectedTokens)
+
+	for range count {
+		b.Deposit()
+	}
+
+	s.InDelta(2.0, b.Tokens(), 0, unexpectedTokens)
+}
+
+func (s *suite) TestBudgetConcurrent() {
+	b := NewBudget(1, concurrency)
+
+	var wg sync.WaitGroup
+
+	for range concurrency {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+
+			b.Withdraw()
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			b.Deposit()
+		}()
+	}
+
+	wg.Wait()
+
+	s.LessOrEqual(b.Tokens(), float64(concurrency), unexpectedTokens)
+}
+
+func (s *suite) TestSharedBudget() {
+	ctrl := gomock.NewController(s.T())
+	next := NewMockRoundTripper(ctrl)
+	b := NewBudget(0.1, 2)
+
+	first := New(next).
+		WithPause(0).
+		WithLimit(count).
+		WithBudget(b)
+	second := New(next).
+		WithPause(0).
+		WithLimit(count).
+		WithBudget(b)
+
+	next.EXPECT().
+		RoundTrip(gomock.Any()).
+		DoAndReturn(func(_ *http.Request) (*http.Response, error) {
+			rec := httptest.NewRecorder()
+			rec.WriteHeader(http.StatusServiceUnavailable)
+
+			return rec.Result(), nil
+		}).
+		Times(4)
+
+	request, _ := http.NewRequest(http.MethodGet, URL, nil)
+
+	res, err := first.RoundTrip(request)
+	s.Require().NoError(err, unexpectedError)
+	s.Equal(http.StatusServiceUnavailable, res.StatusCode, unexpectedResponse)
+	s.Equal(uint(3), first.Count(), unexpectedRecCount)
+
+	res, err = second.RoundTrip(request)
+	s.Require().NoError(err, unexpectedError)
+	s.Equal(http.StatusServiceUnavailable, res.StatusCode, unexpectedResponse)
+	s.Equal(uint(1), second.Count(), unexpectedRecCount)
+
+	next.EXPECT().
+		RoundTrip(gomock.Any()).
+		DoAndReturn(func(_ *http.Request) (*http.Response, error) {
+			return httptest.NewRecorder().Result(), nil
+		})
+
+	res, err = second.RoundTrip(request)
+	s.Require().NoError(err, unexpectedError)
+	s.Equal(http.StatusOK, res.StatusCode, unexpectedResponse)
+	s.InDelta(0.1, b.Tokens(), 1e-9, unexpectedTokens)
+}
@@ -0,0 +1,175 @@
+package retry
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// call holds state of single request processing.
+type call struct {
+	ctx      context.Context
+	h        *HTTPRetry
+	validate func(res *http.Response, err error) bool
+	req      *http.Request
+	body     *body
+	res      *http.Response
+	err      error
+	cancel   func()
+	stop     func()
+	deadline time.Time
+	attempts []Attempt
+	pause    time.Duration
+	limit    int
+	attempt  uint
+	retry    bool
+}
+
+// newCall prepares request processing.
+func (h *HTTPRetry) newCall(req *http.Request) (*call, error) {
+	req = h.withIdempotencyKey(req)
+
+	b, err := newBody(req, h.bufferLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, stop := h.context(req.Context())
+
+	c := &call{
+		ctx:      ctx,
+		h:        h,
+		req:      req,
+		body:     b,
+		stop:     stop,
+		deadline: h.deadline(),
+		retry:    h.retryable(req),
+	}
+	c.applyOverrides()
+
+	return c, nil
+}
+
+// run makes request attempts until valid response received or retries exhausted.
+func (c *call) run() (*http.Response, error) {
+	for attempt := uint(1); c.checkLimit(attempt); attempt++ {
+		if attempt > 1 {
+			next, err := c.wait(attempt)
+			if err != nil {
+				return nil, err
+			}
+
+			if !next {
+				break
+			}
+		}
+
+		if err := c.do(attempt); err != nil {
+			return nil, err
+		}
+
+		if c.validate(c.res, c.err) {
+			if c.h.budget != nil {
+				c.h.budget.Deposit()
+			}
+
+			return finish(c.res, c.err, c.cancel, c.stop)
+		}
+	}
+
+	return c.exhausted()
+}
+
+// checkLimit reports whether attempt is allowed by limit.
+func (c *call) checkLimit(attempt uint) bool {
+	return c.limit < 0 || attempt <= uint(c.limit)
+}
+
+// wait pauses before next attempt. Returns false when retries must be stopped.
+func (c *call) wait(attempt uint) (bool, error) {
+	if !c.retry {
+		return false, nil
+	}
+
+	if !c.body.replayable() {
+		err := notReplayable(c.res, c.err)
+		c.giveUp()
+		discard(c.res, c.h.drainLimit)
+		release(c.cancel, c.stop)
+
+		return false, err
+	}
+
+	c.pause = c.h.pause(c.res, attempt-1, c.pause)
+	if !c.deadline.IsZero() && c.h.clock.Now().Add(c.pause).After(c.deadline) {
+		return false, nil
+	}
+
+	if c.h.budget != nil && !c.h.budget.Withdraw() {
+		return false, nil
+	}
+
+	c.retrying()
+	discard(c.res, c.h.drainLimit)
+	release(c.cancel)
+
+	start := c.h.clock.Now()
+	err := c.h.clock.Sleep(c.ctx, c.pause)
+	c.h.stats.pause.Add(int64(c.h.clock.Now().Sub(start)))
+
+	if err != nil {
+		release(c.stop)
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+// do makes single request attempt.
+func (c *call) do(attempt uint) error {
+	rc, err := c.body.next()
+	if err != nil {
+		release(c.stop)
+
+		return err
+	}
+
+	start := c.h.clock.Now()
+	c.attempt = attempt
+	c.res, c.cancel, c.err = c.h.doRequest(c.ctx, c.req, rc, attempt)
+	c.attempts = append(c.attempts, Attempt{
+		Err:      c.err,
+		Duration: c.h.clock.Now().Sub(start),
+		Status:   status(c.res),
+	})
+
+	if c.err != nil && c.h.log != nil {
+		c.h.log.Error(c.err.Error())
+	}
+
+	c.h.done.Add(1)
+
+	return nil
+}
+
+// exhausted returns result of request which retries are exhausted.
+func (c *call) exhausted() (*http.Response, error) {
+	c.giveUp()
+
+	if res := c.fallback(); res != nil {
+		discard(c.res, c.h.drainLimit)
+		release(c.cancel, c.stop)
+
+		return res, nil
+	}
+
+	if !c.h.exhaustedErr {
+		return finish(c.res, c.err, c.cancel, c.stop)
+	}
+
+	discard(c.res, c.h.drainLimit)
+	release(c.cancel, c.stop)
+
+	return nil, &ExhaustedError{Attempts: c.attempts}
+}
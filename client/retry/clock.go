@@ -0,0 +1,32 @@
+package retry
+
+import (
+	"context"
+	"time"
+)
+
+// Clock provides time for [HTTPRetry]. Fake implementation from retrytest package makes pauses and timeouts
+// instant and deterministic in tests.
+type Clock interface {
+	Now() time.Time
+	Sleep(ctx context.Context, d time.Duration) error
+	WithTimeout(parent context.Context, d time.Duration) (context.Context, context.CancelFunc)
+}
+
+// systemClock is a [Clock] implementation with real time.
+type systemClock struct{}
+
+// Now returns current time.
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// Sleep pauses until duration elapsed or context is done.
+func (systemClock) Sleep(ctx context.Context, d time.Duration) error {
+	return sleep(ctx, d)
+}
+
+// WithTimeout returns context which is canceled after timeout.
+func (systemClock) WithTimeout(parent context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(parent, d)
+}
@@ -0,0 +1,127 @@
+package retry
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/nafigator/http/client/retry/retrytest"
+	"github.com/nafigator/http/headers"
+)
+
+const unexpectedSleeps = "Unexpected pauses"
+
+type clockCase struct {
+	setup          func(r *HTTPRetry, clock *retrytest.Clock)
+	respond        func(req *http.Request, clock *retrytest.Clock) (*http.Response, error)
+	name           string
+	expectedSleeps []time.Duration
+	expectedCalls  int
+	expectedStatus int
+}
+
+func (s *suite) TestClock() {
+	for _, c := range clockProvider() {
+		s.Run(c.name, func() {
+			ctrl := gomock.NewController(s.T())
+			next := NewMockRoundTripper(ctrl)
+			clock := retrytest.NewClock(time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC))
+
+			r := New(next).
+				WithLimit(count).
+				WithClock(clock)
+			c.setup(r, clock)
+
+			next.EXPECT().
+				RoundTrip(gomock.Any()).
+				DoAndReturn(func(req *http.Request) (*http.Response, error) {
+					return c.respond(req, clock)
+				}).
+				Times(c.expectedCalls)
+
+			request, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, URL, nil)
+
+			res, err := r.RoundTrip(request)
+
+			s.Require().NoError(err, unexpectedError)
+			s.Equal(c.expectedStatus, res.StatusCode, unexpectedResponse)
+			s.Equal(c.expectedSleeps, clock.Sleeps(), unexpectedSleeps)
+		})
+	}
+}
+
+func clockProvider() []clockCase {
+	unavailable := func(_ *http.Request, _ *retrytest.Clock) (*http.Response, error) {
+		rec := httptest.NewRecorder()
+		rec.WriteHeader(http.StatusServiceUnavailable)
+
+		return rec.Result(), nil
+	}
+
+	var calls int
+
+	return []clockCase{
+		{
+			name: "backoff schedule",
+			setup: func(r *HTTPRetry, _ *retrytest.Clock) {
+				r.WithBackoff(NewExponential(time.Second, time.Minute, 2))
+			},
+			respond:        unavailable,
+			expectedSleeps: []time.Duration{time.Second, 2 * time.Second, 4 * time.Second},
+			expectedCalls:  count,
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name: "retry after date",
+			setup: func(r *HTTPRetry, _ *retrytest.Clock) {
+				r.WithPause(time.Second)
+			},
+			respond: func(_ *http.Request, clock *retrytest.Clock) (*http.Response, error) {
+				rec := httptest.NewRecorder()
+				rec.Header().Set(headers.RetryAfter, clock.Now().Add(time.Minute).Format(http.TimeFormat))
+				rec.WriteHeader(http.StatusTooManyRequests)
+
+				return rec.Result(), nil
+			},
+			expectedSleeps: []time.Duration{time.Minute, time.Minute, time.Minute},
+			expectedCalls:  count,
+			expectedStatus: http.StatusTooManyRequests,
+		},
+		{
+			name: "time budget",
+			setup: func(r *HTTPRetry, _ *retrytest.Clock) {
+				r.WithPause(2 * time.Second).
+					WithMaxElapsed(5 * time.Second)
+			},
+			respond:        unavailable,
+			expectedSleeps: []time.Duration{2 * time.Second, 2 * time.Second},
+			expectedCalls:  3,
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name: "attempt timeout",
+			setup: func(r *HTTPRetry, _ *retrytest.Clock) {
+				r.WithPause(time.Second).
+					WithTimeout(10 * time.Second)
+			},
+			respond: func(req *http.Request, clock *retrytest.Clock) (*http.Response, error) {
+				calls++
+
+				if calls == 1 {
+					clock.Advance(10 * time.Second)
+					<-req.Context().Done()
+
+					return nil, req.Context().Err()
+				}
+
+				return httptest.NewRecorder().Result(), nil
+			},
+			expectedSleeps: []time.Duration{time.Second},
+			expectedCalls:  2,
+			expectedStatus: http.StatusOK,
+		},
+	}
+}
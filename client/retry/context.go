@@ -0,0 +1,89 @@
+package retry
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// releaseBody calls release function after response body closing.
+type releaseBody struct {
+	io.ReadCloser
+
+	release func()
+}
+
+// Close [io.Closer] implementation.
+func (b *releaseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+
+	return err
+}
+
+// context returns request context which is also canceled with context provided by [HTTPRetry.WithCancel].
+// Returned stop function is nil when there is nothing to release.
+func (h *HTTPRetry) context(parent context.Context) (context.Context, func()) {
+	if h.ctx == nil {
+		return parent, nil
+	}
+
+	ctx, cancel := context.WithCancel(parent)
+	unregister := context.AfterFunc(h.ctx, cancel)
+
+	return ctx, func() {
+		unregister()
+		cancel()
+	}
+}
+
+// sleep pauses until duration elapsed or context is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// finish binds release of request resources to response body closing.
+func finish(res *http.Response, err error, funcs ...func()) (*http.Response, error) {
+	if res == nil || res.Body == nil {
+		release(funcs...)
+
+		return res, err
+	}
+
+	for _, f := range funcs {
+		if f != nil {
+			res.Body = &releaseBody{
+				ReadCloser: res.Body,
+				release: func() {
+					release(funcs...)
+				},
+			}
+
+			break
+		}
+	}
+
+	return res, err
+}
+
+// release calls non-nil functions.
+func release(funcs ...func()) {
+	for _, f := range funcs {
+		if f != nil {
+			f()
+		}
+	}
+}
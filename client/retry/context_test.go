@@ -0,0 +1,149 @@
+package retry
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	longPause         = time.Hour
+	unexpectedElapsed = "Unexpected elapsed time"
+)
+
+type cancelCase struct {
+	expectedErr error
+	reqCtx      func() (context.Context, context.CancelFunc)
+	retryCtx    func() (context.Context, context.CancelFunc)
+	name        string
+}
+
+func (s *suite) TestPauseCancel() {
+	for _, c := range cancelProvider() {
+		s.Run(c.name, func() {
+			ctrl := gomock.NewController(s.T())
+			next := NewMockRoundTripper(ctrl)
+
+			reqCtx, reqCancel := c.reqCtx()
+			defer reqCancel()
+
+			retryCtx, retryCancel := c.retryCtx()
+			defer retryCancel()
+
+			r := New(next).
+				WithPause(longPause).
+				WithLimit(count).
+				WithCancel(retryCtx)
+
+			next.EXPECT().
+				RoundTrip(gomock.Any()).
+				DoAndReturn(func(_ *http.Request) (*http.Response, error) {
+					rec := httptest.NewRecorder()
+					rec.WriteHeader(http.StatusServiceUnavailable)
+
+					return rec.Result(), nil
+				}).
+				Times(1)
+
+			request, _ := http.NewRequestWithContext(reqCtx, http.MethodGet, URL, nil)
+			start := time.Now()
+			res, err := r.RoundTrip(request)
+
+			s.Nil(res, unexpectedResponse)
+			s.Require().ErrorIs(err, c.expectedErr, unexpectedError)
+			s.Less(time.Since(start), longPause, unexpectedElapsed)
+		})
+	}
+}
+
+func (s *suite) TestMaxElapsed() {
+	ctrl := gomock.NewController(s.T())
+	next := NewMockRoundTripper(ctrl)
+
+	r := New(next).
+		WithPause(longPause).
+		WithLimit(count).
+		WithMaxElapsed(time.Minute)
+
+	next.EXPECT().
+		RoundTrip(gomock.Any()).
+		DoAndReturn(func(_ *http.Request) (*http.Response, error) {
+			rec := httptest.NewRecorder()
+			rec.WriteHeader(http.StatusServiceUnavailable)
+
+			return rec.Result(), nil
+		}).
+		Times(1)
+
+	request, _ := http.NewRequest(http.MethodGet, URL, nil)
+	start := time.Now()
+	res, err := r.RoundTrip(request)
+
+	s.Require().NoError(err, unexpectedError)
+	s.Equal(http.StatusServiceUnavailable, res.StatusCode, unexpectedResponse)
+	s.Less(time.Since(start), time.Minute, unexpectedElapsed)
+}
+
+func (s *suite) TestResponseBodyAfterTimeout() {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(payload))
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	r := New(srv.Client().Transport).
+		WithTimeout(time.Minute).
+		WithCancel(ctx)
+	c := http.Client{Transport: r}
+
+	res, err := c.Get(srv.URL)
+	s.Require().NoError(err, unexpectedError)
+
+	b, err := io.ReadAll(res.Body)
+	s.Require().NoError(err, unexpectedError)
+	s.Require().NoError(res.Body.Close(), unexpectedError)
+	s.Equal(payload, string(b), unexpectedResponse)
+}
+
+func (s *suite) TestSleep() {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	s.Require().NoError(sleep(ctx, 0), unexpectedError)
+	s.Require().NoError(sleep(ctx, time.Nanosecond), unexpectedError)
+
+	cancel()
+
+	s.Require().ErrorIs(sleep(ctx, 0), context.Canceled, unexpectedError)
+	s.Require().ErrorIs(sleep(ctx, longPause), context.Canceled, unexpectedError)
+}
+
+func cancelProvider() []cancelCase {
+	background := func() (context.Context, context.CancelFunc) {
+		return context.WithCancel(context.Background())
+	}
+
+	canceled := func() (context.Context, context.CancelFunc) {
+		return context.WithTimeout(context.Background(), 10*time.Millisecond)
+	}
+
+	return []cancelCase{
+		{
+			name:        "request context",
+			reqCtx:      canceled,
+			retryCtx:    background,
+			expectedErr: context.DeadlineExceeded,
+		},
+		{
+			name:        "retry context",
+			reqCtx:      background,
+			retryCtx:    canceled,
+			expectedErr: context.Canceled,
+		},
+	}
+}
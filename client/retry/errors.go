@@ -0,0 +1,61 @@
+package retry
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ErrRetriesExhausted matches [ExhaustedError] with [errors.Is].
+var ErrRetriesExhausted = errors.New("retries exhausted")
+
+// Attempt describes result of single request attempt.
+type Attempt struct {
+	Err      error
+	Duration time.Duration
+	Status   int
+}
+
+// ExhaustedError returned when all request attempts failed and [HTTPRetry.WithExhaustedError] is enabled.
+type ExhaustedError struct {
+	Attempts []Attempt
+}
+
+// Error [error] implementation.
+func (e *ExhaustedError) Error() string {
+	msg := fmt.Sprintf("%s after %d attempts", ErrRetriesExhausted, len(e.Attempts))
+	if len(e.Attempts) == 0 {
+		return msg
+	}
+
+	last := e.Attempts[len(e.Attempts)-1]
+	if last.Err != nil {
+		return msg + ": " + last.Err.Error()
+	}
+
+	return msg + ": status " + strconv.Itoa(last.Status)
+}
+
+// Unwrap returns [ErrRetriesExhausted] and errors of all attempts.
+func (e *ExhaustedError) Unwrap() []error {
+	errs := []error{ErrRetriesExhausted}
+
+	for _, a := range e.Attempts {
+		if a.Err != nil {
+			errs = append(errs, a.Err)
+		}
+	}
+
+	return errs
+}
+
+// status returns response status code or zero for nil response.
+func status(res *http.Response) int {
+	if res == nil {
+		return 0
+	}
+
+	return res.StatusCode
+}
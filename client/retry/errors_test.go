@@ -0,0 +1,89 @@
+package retry
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"go.uber.org/mock/gomock"
+)
+
+const unexpectedMessage = "Unexpected error message"
+
+type exhaustedMessageCase struct {
+	err      *ExhaustedError
+	name     string
+	expected string
+}
+
+func (s *suite) TestExhaustedError() {
+	ctrl := gomock.NewController(s.T())
+	next := NewMockRoundTripper(ctrl)
+	transportErr := errors.New("connection reset")
+
+	r := New(next).
+		WithPause(0).
+		WithLimit(3).
+		WithExhaustedError(true).
+		WithRespValidator(func(res *http.Response, err error) bool {
+			return err == nil && res.StatusCode < http.StatusInternalServerError
+		})
+
+	response := func(code int) func(*http.Request) (*http.Response, error) {
+		return func(_ *http.Request) (*http.Response, error) {
+			rec := httptest.NewRecorder()
+			rec.WriteHeader(code)
+
+			return rec.Result(), nil
+		}
+	}
+
+	gomock.InOrder(
+		next.EXPECT().RoundTrip(gomock.Any()).DoAndReturn(response(http.StatusServiceUnavailable)),
+		next.EXPECT().RoundTrip(gomock.Any()).Return(nil, transportErr),
+		next.EXPECT().RoundTrip(gomock.Any()).DoAndReturn(response(http.StatusBadGateway)),
+	)
+
+	request, _ := http.NewRequest(http.MethodGet, URL, nil)
+	res, err := r.RoundTrip(request)
+
+	s.Nil(res, unexpectedResponse)
+	s.Require().ErrorIs(err, ErrRetriesExhausted, unexpectedError)
+	s.Require().ErrorIs(err, transportErr, unexpectedError)
+
+	var exhausted *ExhaustedError
+
+	s.Require().ErrorAs(err, &exhausted, unexpectedError)
+	s.Len(exhausted.Attempts, 3, unexpectedAttempts)
+	s.Equal(http.StatusServiceUnavailable, exhausted.Attempts[0].Status, unexpectedResponse)
+	s.Equal(transportErr, exhausted.Attempts[1].Err, unexpectedError)
+	s.Equal(http.StatusBadGateway, exhausted.Attempts[2].Status, unexpectedResponse)
+	s.Require().NoError(exhausted.Attempts[2].Err, unexpectedError)
+	s.EqualError(err, "retries exhausted after 3 attempts: status 502", unexpectedMessage)
+}
+
+func (s *suite) TestExhaustedErrorMessage() {
+	for _, c := range exhaustedMessageProvider() {
+		s.Run(c.name, func() {
+			s.EqualError(c.err, c.expected, unexpectedMessage)
+		})
+	}
+}
+
+func exhaustedMessageProvider() []exhaustedMessageCase {
+	return []exhaustedMessageCase{
+		{
+			name:     "without attempts",
+			err:      &ExhaustedError{},
+			expected: "retries exhausted after 0 attempts",
+		},
+		{
+			name: "last attempt error",
+			err: &ExhaustedError{Attempts: []Attempt{
+				{Status: http.StatusBadGateway},
+				{Err: errors.New("connection reset")},
+			}},
+			expected: "retries exhausted after 2 attempts: connection reset",
+		},
+	}
+}
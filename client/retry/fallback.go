@@ -0,0 +1,41 @@
+package retry
+
+import (
+	"net/http"
+)
+
+// FallbackHeader is a header which marks responses synthesized by fallback function.
+const FallbackHeader = "X-Retry-Fallback"
+
+// IsFallback reports whether response was synthesized by fallback function. See [HTTPRetry.WithFallback].
+func IsFallback(res *http.Response) bool {
+	return res != nil && res.Header.Get(FallbackHeader) != ""
+}
+
+// fallback returns response synthesized by fallback function. Returns nil when there is no fallback.
+func (c *call) fallback() *http.Response {
+	if c.h.fallback == nil {
+		return nil
+	}
+
+	res := c.h.fallback(c.req, &ExhaustedError{Attempts: c.attempts})
+	if res == nil {
+		return nil
+	}
+
+	if res.Header == nil {
+		res.Header = make(http.Header)
+	}
+
+	if res.Body == nil {
+		res.Body = http.NoBody
+	}
+
+	if res.Request == nil {
+		res.Request = c.req
+	}
+
+	res.Header.Set(FallbackHeader, "true")
+
+	return res
+}
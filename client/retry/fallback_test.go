@@ -0,0 +1,149 @@
+package retry
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	unexpectedFallback = "Unexpected fallback"
+	cached             = "cached"
+)
+
+type fallbackCase struct {
+	fallback       func(req *http.Request, err error) *http.Response
+	name           string
+	expectedBody   string
+	expectedStatus int
+	exhaustedErr   bool
+	isFallback     bool
+}
+
+func (s *suite) TestFallback() {
+	for _, c := range fallbackProvider() {
+		s.Run(c.name, func() {
+			ctrl := gomock.NewController(s.T())
+			next := NewMockRoundTripper(ctrl)
+
+			var actualErr error
+
+			r := New(next).
+				WithPause(0).
+				WithLimit(count).
+				WithExhaustedError(c.exhaustedErr).
+				WithFallback(func(req *http.Request, err error) *http.Response {
+					actualErr = err
+
+					return c.fallback(req, err)
+				})
+
+			next.EXPECT().
+				RoundTrip(gomock.Any()).
+				DoAndReturn(func(_ *http.Request) (*http.Response, error) {
+					rec := httptest.NewRecorder()
+					rec.WriteHeader(http.StatusServiceUnavailable)
+					_, _ = rec.WriteString(payload)
+
+					return rec.Result(), nil
+				}).
+				Times(count)
+
+			request, _ := http.NewRequest(http.MethodGet, URL, nil)
+
+			res, err := r.RoundTrip(request)
+
+			var exhausted *ExhaustedError
+
+			s.Require().ErrorAs(actualErr, &exhausted, unexpectedError)
+			s.Len(exhausted.Attempts, count, unexpectedAttempts)
+
+			if c.exhaustedErr && !c.isFallback {
+				s.Require().ErrorIs(err, ErrRetriesExhausted, unexpectedError)
+
+				return
+			}
+
+			s.Require().NoError(err, unexpectedError)
+			s.Equal(c.expectedStatus, res.StatusCode, unexpectedResponse)
+			s.Equal(c.isFallback, IsFallback(res), unexpectedFallback)
+
+			if c.isFallback {
+				s.Equal(request, res.Request, unexpectedResponse)
+			}
+
+			b, err := io.ReadAll(res.Body)
+			s.Require().NoError(err, unexpectedError)
+			s.Require().NoError(res.Body.Close(), unexpectedError)
+			s.Equal(c.expectedBody, string(b), unexpectedResponse)
+		})
+	}
+}
+
+func (s *suite) TestIsFallback() {
+	s.False(IsFallback(nil), unexpectedFallback)
+	s.False(IsFallback(httptest.NewRecorder().Result()), unexpectedFallback)
+}
+
+func fallbackProvider() []fallbackCase {
+	static := func(_ *http.Request, _ error) *http.Response {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(cached)),
+		}
+	}
+
+	none := func(_ *http.Request, _ error) *http.Response {
+		return nil
+	}
+
+	return []fallbackCase{
+		{
+			name:           "static response",
+			fallback:       static,
+			expectedStatus: http.StatusOK,
+			expectedBody:   cached,
+			isFallback:     true,
+		},
+		{
+			name:           "static response instead of error",
+			fallback:       static,
+			exhaustedErr:   true,
+			expectedStatus: http.StatusOK,
+			expectedBody:   cached,
+			isFallback:     true,
+		},
+		{
+			name: "response by error",
+			fallback: func(req *http.Request, err error) *http.Response {
+				var exhausted *ExhaustedError
+				if !errors.As(err, &exhausted) {
+					return nil
+				}
+
+				return &http.Response{
+					StatusCode: exhausted.Attempts[len(exhausted.Attempts)-1].Status,
+					Header:     http.Header{"Cache-Control": {"no-store"}},
+					Request:    req,
+				}
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+			isFallback:     true,
+		},
+		{
+			name:           "no fallback",
+			fallback:       none,
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody:   payload,
+		},
+		{
+			name:         "no fallback with error",
+			fallback:     none,
+			exhaustedErr: true,
+		},
+	}
+}
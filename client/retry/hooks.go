@@ -0,0 +1,51 @@
+package retry
+
+import (
+	"net/http"
+	"time"
+)
+
+// AttemptHeader is a conventional header name for attempt number of retried requests.
+const AttemptHeader = "X-Retry-Attempt"
+
+// Event describes failed request attempt for retry hooks.
+type Event struct {
+	Request *http.Request
+	Err     error
+	Attempt uint
+	Delay   time.Duration
+	Status  int
+}
+
+// event returns description of the last request attempt.
+func (c *call) event(delay time.Duration) Event {
+	return Event{
+		Request: c.req,
+		Err:     c.err,
+		Attempt: c.attempt,
+		Delay:   delay,
+		Status:  status(c.res),
+	}
+}
+
+// retrying counts retry and calls retry hook before pause.
+func (c *call) retrying() {
+	if c.err != nil {
+		c.h.stats.networkRetries.Add(1)
+	} else {
+		c.h.stats.statusRetries.Add(1)
+	}
+
+	if c.h.onRetry != nil {
+		c.h.onRetry(c.event(c.pause))
+	}
+}
+
+// giveUp counts give up and calls give up hook when failed request won't be retried anymore.
+func (c *call) giveUp() {
+	c.h.stats.giveUps.Add(1)
+
+	if c.h.onGiveUp != nil {
+		c.h.onGiveUp(c.event(0))
+	}
+}
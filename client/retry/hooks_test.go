@@ -0,0 +1,160 @@
+package retry
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	unexpectedEvents  = "Unexpected hook events"
+	unexpectedHeaders = "Unexpected attempt headers"
+)
+
+type hooksCase struct {
+	request         func() *http.Request
+	name            string
+	statuses        []int
+	expectedRetries []Event
+	expectedGiveUps []Event
+	expectedHeaders []string
+	expectedStatus  int
+	attemptHeader   string
+	limit           int
+}
+
+func (s *suite) TestHooks() {
+	for _, c := range hooksProvider() {
+		s.Run(c.name, func() {
+			ctrl := gomock.NewController(s.T())
+			next := NewMockRoundTripper(ctrl)
+
+			var retries, giveUps []Event
+			var actualHeaders []string
+
+			r := New(next).
+				WithPause(time.Nanosecond).
+				WithLimit(c.limit).
+				WithAttemptHeader(c.attemptHeader).
+				WithOnRetry(func(e Event) {
+					e.Request = nil
+					retries = append(retries, e)
+				}).
+				WithOnGiveUp(func(e Event) {
+					e.Request = nil
+					giveUps = append(giveUps, e)
+				})
+
+			calls := make([]any, 0, len(c.statuses))
+
+			for _, code := range c.statuses {
+				calls = append(calls, next.EXPECT().
+					RoundTrip(gomock.Any()).
+					DoAndReturn(func(req *http.Request) (*http.Response, error) {
+						actualHeaders = append(actualHeaders, req.Header.Get(AttemptHeader))
+
+						rec := httptest.NewRecorder()
+						rec.WriteHeader(code)
+
+						return rec.Result(), nil
+					}))
+			}
+
+			gomock.InOrder(calls...)
+
+			res, err := r.RoundTrip(c.request())
+
+			if c.expectedStatus == 0 {
+				s.Require().ErrorIs(err, ErrBodyNotReplayable, unexpectedError)
+			} else {
+				s.Require().NoError(err, unexpectedError)
+				s.Equal(c.expectedStatus, res.StatusCode, unexpectedResponse)
+			}
+
+			s.Equal(c.expectedRetries, retries, unexpectedEvents)
+			s.Equal(c.expectedGiveUps, giveUps, unexpectedEvents)
+			s.Equal(c.expectedHeaders, actualHeaders, unexpectedHeaders)
+		})
+	}
+}
+
+func (s *suite) TestGiveUpOnError() {
+	ctrl := gomock.NewController(s.T())
+	next := NewMockRoundTripper(ctrl)
+
+	var giveUp Event
+
+	r := New(next).
+		WithPause(0).
+		WithLimit(1).
+		WithRespValidator(ValidateNetwork).
+		WithOnGiveUp(func(e Event) {
+			giveUp = e
+		})
+
+	next.EXPECT().
+		RoundTrip(gomock.Any()).
+		Return(nil, io.EOF)
+
+	request, _ := http.NewRequest(http.MethodGet, URL, nil)
+	_, err := r.RoundTrip(request)
+
+	s.Require().ErrorIs(err, io.EOF, unexpectedError)
+	s.Equal(Event{Request: request, Err: io.EOF, Attempt: 1}, giveUp, unexpectedEvents)
+}
+
+func hooksProvider() []hooksCase {
+	getRequest := func() *http.Request {
+		req, _ := http.NewRequest(http.MethodGet, URL, nil)
+
+		return req
+	}
+
+	return []hooksCase{
+		{
+			name:           "success after retries",
+			request:        getRequest,
+			limit:          count,
+			attemptHeader:  AttemptHeader,
+			statuses:       []int{http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK},
+			expectedStatus: http.StatusOK,
+			expectedRetries: []Event{
+				{Attempt: 1, Delay: time.Nanosecond, Status: http.StatusServiceUnavailable},
+				{Attempt: 2, Delay: time.Nanosecond, Status: http.StatusBadGateway},
+			},
+			expectedHeaders: []string{"", "2", "3"},
+		},
+		{
+			name:           "exhausted retries",
+			request:        getRequest,
+			limit:          2,
+			statuses:       []int{http.StatusServiceUnavailable, http.StatusBadGateway},
+			expectedStatus: http.StatusBadGateway,
+			expectedRetries: []Event{
+				{Attempt: 1, Delay: time.Nanosecond, Status: http.StatusServiceUnavailable},
+			},
+			expectedGiveUps: []Event{
+				{Attempt: 2, Status: http.StatusBadGateway},
+			},
+			expectedHeaders: []string{"", ""},
+		},
+		{
+			name: "not replayable body",
+			request: func() *http.Request {
+				req, _ := http.NewRequest(http.MethodPost, URL, io.NopCloser(strings.NewReader(payload)))
+
+				return req
+			},
+			limit:    count,
+			statuses: []int{http.StatusServiceUnavailable},
+			expectedGiveUps: []Event{
+				{Attempt: 1, Status: http.StatusServiceUnavailable},
+			},
+			expectedHeaders: []string{""},
+		},
+	}
+}
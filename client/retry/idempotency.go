@@ -0,0 +1,62 @@
+package retry
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+const (
+	idempotencyKey = "Idempotency-Key"
+
+	uuidSize       = 16
+	uuidVersionIdx = 6
+	uuidVersion    = 0x40
+	uuidVersionMsk = 0x0f
+	uuidVariantIdx = 8
+	uuidVariant    = 0x80
+	uuidVariantMsk = 0x3f
+)
+
+// NewIdempotencyKey returns random UUID version 4 string for Idempotency-Key header.
+func NewIdempotencyKey() string {
+	var b [uuidSize]byte
+
+	_, _ = rand.Read(b[:])
+
+	b[uuidVersionIdx] = b[uuidVersionIdx]&uuidVersionMsk | uuidVersion
+	b[uuidVariantIdx] = b[uuidVariantIdx]&uuidVariantMsk | uuidVariant
+
+	s := hex.EncodeToString(b[:])
+
+	return s[:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:]
+}
+
+// idempotent reports whether HTTP method is idempotent by RFC 9110.
+func idempotent(method string) bool {
+	switch method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut,
+		http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// withIdempotencyKey returns request copy with generated Idempotency-Key header for non-idempotent requests
+// without this header.
+func (h *HTTPRetry) withIdempotencyKey(req *http.Request) *http.Request {
+	if h.keygen == nil || idempotent(req.Method) || req.Header.Get(idempotencyKey) != "" {
+		return req
+	}
+
+	r := req.Clone(req.Context())
+	r.Header.Set(idempotencyKey, h.keygen())
+
+	return r
+}
+
+// retryable reports whether request may be retried with respect of idempotency policy.
+func (h *HTTPRetry) retryable(req *http.Request) bool {
+	return !h.idempotentOnly || idempotent(req.Method) || req.Header.Get(idempotencyKey) != ""
+}
@@ -0,0 +1,135 @@
+package retry
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	generatedKey  = "generated-key"
+	unexpectedKey = "Unexpected idempotency key"
+)
+
+type idempotencyCase struct {
+	keygen        func() string
+	name          string
+	method        string
+	key           string
+	expectedKey   string
+	expectedCalls int
+}
+
+func (s *suite) TestIdempotency() {
+	for _, c := range idempotencyProvider() {
+		s.Run(c.name, func() {
+			ctrl := gomock.NewController(s.T())
+			next := NewMockRoundTripper(ctrl)
+
+			r := New(next).
+				WithPause(0).
+				WithLimit(count).
+				WithIdempotentOnly(true).
+				WithIdempotencyKey(c.keygen)
+
+			var keys []string
+
+			next.EXPECT().
+				RoundTrip(gomock.Any()).
+				DoAndReturn(func(req *http.Request) (*http.Response, error) {
+					keys = append(keys, req.Header.Get(idempotencyKey))
+
+					rec := httptest.NewRecorder()
+					rec.WriteHeader(http.StatusServiceUnavailable)
+
+					return rec.Result(), nil
+				}).
+				Times(c.expectedCalls)
+
+			request, _ := http.NewRequest(c.method, URL, bytes.NewBufferString(payload))
+			if c.key != "" {
+				request.Header.Set(idempotencyKey, c.key)
+			}
+
+			res, err := r.RoundTrip(request)
+
+			s.Require().NoError(err, unexpectedError)
+			s.Equal(http.StatusServiceUnavailable, res.StatusCode, unexpectedResponse)
+			s.Equal(c.key, request.Header.Get(idempotencyKey), unexpectedKey)
+
+			for _, k := range keys {
+				s.Equal(c.expectedKey, k, unexpectedKey)
+			}
+		})
+	}
+}
+
+func (s *suite) TestNewIdempotencyKey() {
+	re := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+	first := NewIdempotencyKey()
+	second := NewIdempotencyKey()
+
+	s.Regexp(re, first, unexpectedKey)
+	s.Regexp(re, second, unexpectedKey)
+	s.NotEqual(first, second, unexpectedKey)
+}
+
+func idempotencyProvider() []idempotencyCase {
+	keygen := func() string {
+		return generatedKey
+	}
+
+	var generated int
+
+	counter := func() string {
+		generated++
+
+		return generatedKey + "-" + strconv.Itoa(generated)
+	}
+
+	return []idempotencyCase{
+		{
+			name:          "idempotent method",
+			method:        http.MethodPut,
+			expectedCalls: count,
+		},
+		{
+			name:          "non-idempotent method",
+			method:        http.MethodPost,
+			expectedCalls: 1,
+		},
+		{
+			name:          "non-idempotent method with key",
+			method:        http.MethodPost,
+			key:           "client-key",
+			expectedKey:   "client-key",
+			expectedCalls: count,
+		},
+		{
+			name:          "non-idempotent method with generated key",
+			method:        http.MethodPatch,
+			keygen:        counter,
+			expectedKey:   generatedKey + "-1",
+			expectedCalls: count,
+		},
+		{
+			name:          "idempotent method without generated key",
+			method:        http.MethodDelete,
+			keygen:        keygen,
+			expectedCalls: count,
+		},
+		{
+			name:          "non-idempotent method with key and generator",
+			method:        http.MethodPost,
+			key:           "client-key",
+			keygen:        keygen,
+			expectedKey:   "client-key",
+			expectedCalls: count,
+		},
+	}
+}
@@ -0,0 +1,73 @@
+package retry
+
+import (
+	"context"
+	"net/http"
+)
+
+// overrideKey is a context key for per-request settings.
+type overrideKey struct{}
+
+// override holds per-request settings which replace [HTTPRetry] settings.
+type override struct {
+	validate func(res *http.Response, err error) bool
+	limit    int
+	hasLimit bool
+	disabled bool
+}
+
+// WithAttempts returns context which overrides attempts limit for request. See [HTTPRetry.WithLimit].
+// Zero limit makes single attempt, same as [Disable].
+func WithAttempts(ctx context.Context, n int) context.Context {
+	if n == 0 {
+		return Disable(ctx)
+	}
+
+	o := overrides(ctx)
+	o.limit = n
+	o.hasLimit = true
+
+	return context.WithValue(ctx, overrideKey{}, o)
+}
+
+// Disable returns context which disables retries for request.
+func Disable(ctx context.Context) context.Context {
+	o := overrides(ctx)
+	o.disabled = true
+
+	return context.WithValue(ctx, overrideKey{}, o)
+}
+
+// WithValidator returns context which overrides response validator for request. See [HTTPRetry.WithRespValidator].
+func WithValidator(ctx context.Context, f func(res *http.Response, err error) bool) context.Context {
+	o := overrides(ctx)
+	o.validate = f
+
+	return context.WithValue(ctx, overrideKey{}, o)
+}
+
+// overrides returns per-request settings from context.
+func overrides(ctx context.Context) override {
+	o, _ := ctx.Value(overrideKey{}).(override)
+
+	return o
+}
+
+// applyOverrides sets request settings with respect of context overrides.
+func (c *call) applyOverrides() {
+	o := overrides(c.req.Context())
+
+	c.limit = c.h.limit
+	if o.hasLimit {
+		c.limit = o.limit
+	}
+
+	c.validate = c.h.validate
+	if o.validate != nil {
+		c.validate = o.validate
+	}
+
+	if o.disabled {
+		c.retry = false
+	}
+}
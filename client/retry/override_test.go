@@ -0,0 +1,101 @@
+package retry
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	"go.uber.org/mock/gomock"
+)
+
+type overrideCase struct {
+	ctx           func() context.Context
+	name          string
+	expectedCalls int
+}
+
+func (s *suite) TestOverrides() {
+	for _, c := range overrideProvider() {
+		s.Run(c.name, func() {
+			ctrl := gomock.NewController(s.T())
+			next := NewMockRoundTripper(ctrl)
+
+			r := New(next).
+				WithPause(0).
+				WithLimit(count)
+
+			next.EXPECT().
+				RoundTrip(gomock.Any()).
+				DoAndReturn(func(_ *http.Request) (*http.Response, error) {
+					rec := httptest.NewRecorder()
+					rec.WriteHeader(http.StatusServiceUnavailable)
+
+					return rec.Result(), nil
+				}).
+				Times(c.expectedCalls)
+
+			request, _ := http.NewRequestWithContext(c.ctx(), http.MethodGet, URL, nil)
+
+			res, err := r.RoundTrip(request)
+
+			s.Require().NoError(err, unexpectedError)
+			s.Equal(http.StatusServiceUnavailable, res.StatusCode, unexpectedResponse)
+		})
+	}
+}
+
+func overrideProvider() []overrideCase {
+	accept := func(_ *http.Response, _ error) bool {
+		return true
+	}
+
+	return []overrideCase{
+		{
+			name:          "without overrides",
+			ctx:           context.Background,
+			expectedCalls: count,
+		},
+		{
+			name: "attempts",
+			ctx: func() context.Context {
+				return WithAttempts(context.Background(), 2)
+			},
+			expectedCalls: 2,
+		},
+		{
+			name: "more attempts",
+			ctx: func() context.Context {
+				return WithAttempts(context.Background(), count*2)
+			},
+			expectedCalls: count * 2,
+		},
+		{
+			name: "zero attempts",
+			ctx: func() context.Context {
+				return WithAttempts(context.Background(), 0)
+			},
+			expectedCalls: 1,
+		},
+		{
+			name: "disabled",
+			ctx: func() context.Context {
+				return Disable(WithAttempts(context.Background(), count*2))
+			},
+			expectedCalls: 1,
+		},
+		{
+			name: "validator",
+			ctx: func() context.Context {
+				return WithValidator(context.Background(), accept)
+			},
+			expectedCalls: 1,
+		},
+		{
+			name: "validator and attempts",
+			ctx: func() context.Context {
+				return WithAttempts(WithValidator(context.Background(), ValidateStatus(http.StatusBadGateway)), 2)
+			},
+			expectedCalls: 1,
+		},
+	}
+}
@@ -0,0 +1,18 @@
+package retry
+
+import (
+	"io"
+	"net/http"
+)
+
+const defaultDrainLimit = 4 << 10
+
+// discard drains up to limit bytes of response body and closes it, so connection can be reused.
+func discard(res *http.Response, limit int64) {
+	if res == nil || res.Body == nil {
+		return
+	}
+
+	_, _ = io.CopyN(io.Discard, res.Body, limit)
+	_ = res.Body.Close()
+}
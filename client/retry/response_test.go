@@ -0,0 +1,110 @@
+package retry
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const unexpectedConnections = "Unexpected connections count"
+
+type drainCase struct {
+	name                string
+	body                string
+	drainLimit          int64
+	exhaustedErr        bool
+	expectedConnections int64
+}
+
+func (s *suite) TestDrainDiscarded() {
+	for _, c := range drainProvider() {
+		s.Run(c.name, func() {
+			var connections, attempts atomic.Int64
+
+			srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				if attempts.Add(1) < count {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					_, _ = w.Write([]byte(c.body))
+
+					return
+				}
+
+				w.WriteHeader(http.StatusOK)
+			}))
+			srv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+				if state == http.StateNew {
+					connections.Add(1)
+				}
+			}
+			srv.Start()
+			defer srv.Close()
+
+			r := New(srv.Client().Transport).
+				WithPause(0).
+				WithLimit(count).
+				WithDrainLimit(c.drainLimit)
+
+			request, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+			res, err := r.RoundTrip(request)
+
+			s.Require().NoError(err, unexpectedError)
+			s.Require().NoError(res.Body.Close(), unexpectedError)
+			s.Equal(http.StatusOK, res.StatusCode, unexpectedResponse)
+			s.Equal(c.expectedConnections, connections.Load(), unexpectedConnections)
+		})
+	}
+}
+
+func (s *suite) TestDrainExhausted() {
+	var active atomic.Int64
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(payload))
+	}))
+	srv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		switch state {
+		case http.StateActive:
+			active.Add(1)
+		case http.StateIdle:
+			active.Add(-1)
+		case http.StateNew, http.StateHijacked, http.StateClosed:
+		}
+	}
+	srv.Start()
+	defer srv.Close()
+
+	r := New(srv.Client().Transport).
+		WithPause(0).
+		WithLimit(count).
+		WithExhaustedError(true)
+
+	request, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	res, err := r.RoundTrip(request)
+
+	s.Nil(res, unexpectedResponse)
+	s.Require().ErrorIs(err, ErrRetriesExhausted, unexpectedError)
+	s.Eventually(func() bool {
+		return active.Load() == 0
+	}, time.Second, time.Millisecond, unexpectedConnections)
+}
+
+func drainProvider() []drainCase {
+	return []drainCase{
+		{
+			name:                "drained bodies",
+			body:                payload,
+			drainLimit:          defaultDrainLimit,
+			expectedConnections: 1,
+		},
+		{
+			name:                "bodies larger than drain limit",
+			body:                strings.Repeat(payload, 100000),
+			drainLimit:          1,
+			expectedConnections: count,
+		},
+	}
+}
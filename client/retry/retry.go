@@ -3,31 +3,55 @@ package retry
 
 import (
 	"context"
-	"math"
+	"io"
 	"net/http"
+	"strconv"
+	"sync/atomic"
 	"time"
 )
 
 const (
-	defaultLimit = 10
-	defaultPause = 30 * time.Second
-	Forever      = -1 // Negative limit value causes make MaxUint attempts.
+	defaultLimit         = 10
+	defaultPause         = 30 * time.Second
+	defaultMaxRetryAfter = 5 * time.Minute
+	Forever              = -1 // Negative limit value causes make MaxUint attempts.
 )
 
 type logger interface {
 	Error(args ...any)
 }
 
+type budget interface {
+	Withdraw() bool
+	Deposit()
+}
+
+// attemptKey is a context key for request attempt number.
+type attemptKey struct{}
+
 type HTTPRetry struct {
-	ctx      context.Context
-	next     http.RoundTripper
-	log      logger
-	validate func(res *http.Response, err error) bool
-	limit    int
-	current  uint
-	done     uint
-	pause    time.Duration
-	timeout  time.Duration
+	ctx            context.Context
+	next           http.RoundTripper
+	log            logger
+	validate       func(res *http.Response, err error) bool
+	backoff        Backoff
+	budget         budget
+	clock          Clock
+	keygen         func() string
+	onRetry        func(Event)
+	onGiveUp       func(Event)
+	fallback       func(req *http.Request, err error) *http.Response
+	attemptHeader  string
+	done           atomic.Uint64
+	stats          stats
+	limit          int
+	timeout        time.Duration
+	maxRetryAfter  time.Duration
+	maxElapsed     time.Duration
+	bufferLimit    int64
+	drainLimit     int64
+	exhaustedErr   bool
+	idempotentOnly bool
 }
 
 // New creates http retry repeater instance.
@@ -35,52 +59,45 @@ func New(
 	next http.RoundTripper,
 ) *HTTPRetry {
 	return &HTTPRetry{
-		next:    next,
-		limit:   defaultLimit,
-		current: 1,
-		pause:   defaultPause,
-		validate: func(res *http.Response, _ error) bool {
-			if res != nil && (res.StatusCode == http.StatusBadGateway ||
-				res.StatusCode == http.StatusServiceUnavailable ||
-				res.StatusCode == http.StatusGatewayTimeout) {
-				return false
-			}
-
-			return true
-		},
+		next:          next,
+		limit:         defaultLimit,
+		backoff:       NewConstant(defaultPause),
+		clock:         systemClock{},
+		maxRetryAfter: defaultMaxRetryAfter,
+		drainLimit:    defaultDrainLimit,
+		validate:      ValidateDefault,
 	}
 }
 
 // RoundTrip [http.RoundTripper] implementation.
 func (h *HTTPRetry) RoundTrip(req *http.Request) (*http.Response, error) {
-	var err error
-	var res *http.Response
+	h.stats.requests.Add(1)
 
-	for h.checkLimit() {
-		if h.current > 1 {
-			time.Sleep(h.pause)
-		}
-
-		res, err = h.doRequest(req)
-		if err != nil && h.log != nil {
-			h.log.Error(err.Error())
-		}
+	c, err := h.newCall(req)
+	if err != nil {
+		return nil, err
+	}
 
-		h.done++
+	return c.run()
+}
 
-		if h.validate(res, err) {
-			return res, nil
-		}
+// Count returns total attempts count made by instance for all requests.
+//
+// Deprecated: use [Attempts] for per-request attempts count or [HTTPRetry.Stats] for totals.
+func (h *HTTPRetry) Count() uint {
+	return uint(h.done.Load())
+}
 
-		h.current++
+// Attempts returns attempt number of request which received response. Returns zero for responses not received
+// through [HTTPRetry].
+func Attempts(res *http.Response) uint {
+	if res == nil || res.Request == nil {
+		return 0
 	}
 
-	return res, err
-}
+	attempt, _ := res.Request.Context().Value(attemptKey{}).(uint)
 
-// Count returns retries count.
-func (h *HTTPRetry) Count() uint {
-	return h.done
+	return attempt
 }
 
 // WithLimit sets retry limit.
@@ -90,34 +107,145 @@ func (h *HTTPRetry) WithLimit(limit int) *HTTPRetry {
 	return h
 }
 
-// WithPause sets retry pause.
+// WithPause sets constant retry pause.
 func (h *HTTPRetry) WithPause(pause time.Duration) *HTTPRetry {
-	h.pause = pause
+	h.backoff = NewConstant(pause)
+
+	return h
+}
+
+// WithBackoff sets strategy for pauses between retries.
+func (h *HTTPRetry) WithBackoff(b Backoff) *HTTPRetry {
+	h.backoff = b
+
+	return h
+}
+
+// WithMaxRetryAfter sets upper limit for pauses requested by server with Retry-After or X-Ratelimit-Reset
+// headers. Zero value disables these headers processing.
+func (h *HTTPRetry) WithMaxRetryAfter(limit time.Duration) *HTTPRetry {
+	h.maxRetryAfter = limit
 
 	return h
 }
 
-// WithTimeout sets requests timeouts.
+// WithBodyBuffer enables in-memory buffering of request bodies up to limit bytes for requests without
+// [http.Request.GetBody]. Larger bodies are sent once without retries.
+func (h *HTTPRetry) WithBodyBuffer(limit int64) *HTTPRetry {
+	h.bufferLimit = limit
+
+	return h
+}
+
+// WithMaxElapsed sets limit for total time spent on request attempts and pauses between them. Retries stop when
+// next pause exceeds this limit. Zero value means no limit.
+func (h *HTTPRetry) WithMaxElapsed(limit time.Duration) *HTTPRetry {
+	h.maxElapsed = limit
+
+	return h
+}
+
+// WithDrainLimit sets max bytes count read from discarded response bodies before closing. Draining allows reuse of
+// keep-alive connections for next attempts.
+func (h *HTTPRetry) WithDrainLimit(limit int64) *HTTPRetry {
+	h.drainLimit = limit
+
+	return h
+}
+
+// WithTimeout sets timeout for every request attempt.
 func (h *HTTPRetry) WithTimeout(timeout time.Duration) *HTTPRetry {
 	h.timeout = timeout
 
 	return h
 }
 
-// WithCancel sets requests contexts.
+// WithCancel sets context which cancels requests and pauses between them in addition to request context.
 func (h *HTTPRetry) WithCancel(ctx context.Context) *HTTPRetry {
 	h.ctx = ctx
 
 	return h
 }
 
-// WithRespValidator sets function for HTTP response validation. Retry on false return.
+// WithRespValidator sets function for HTTP response validation. Retry on false return. See [ValidateDefault],
+// [ValidateStatus], [ValidateNetwork] and [ValidateAll] for built-in validators.
 func (h *HTTPRetry) WithRespValidator(f func(res *http.Response, err error) bool) *HTTPRetry {
 	h.validate = f
 
 	return h
 }
 
+// WithExhaustedError enables returning of [ExhaustedError] instead of the last response when retries exhausted.
+func (h *HTTPRetry) WithExhaustedError(enable bool) *HTTPRetry {
+	h.exhaustedErr = enable
+
+	return h
+}
+
+// WithIdempotentOnly enables retries only for idempotent methods (GET, HEAD, OPTIONS, TRACE, PUT, DELETE) and
+// requests with Idempotency-Key header.
+func (h *HTTPRetry) WithIdempotentOnly(enable bool) *HTTPRetry {
+	h.idempotentOnly = enable
+
+	return h
+}
+
+// WithIdempotencyKey sets generator of Idempotency-Key header for non-idempotent requests without it. Generated key
+// is the same for all attempts of request. Use [NewIdempotencyKey] for random UUID keys or nil to disable generation.
+func (h *HTTPRetry) WithIdempotencyKey(gen func() string) *HTTPRetry {
+	h.keygen = gen
+
+	return h
+}
+
+// WithBudget sets retry budget which may be shared between many instances. Failed requests are returned without
+// retries when budget is exhausted. See [Budget].
+func (h *HTTPRetry) WithBudget(b budget) *HTTPRetry {
+	h.budget = b
+
+	return h
+}
+
+// WithClock sets clock for pauses, timeouts and time budget. Real time is used by default.
+func (h *HTTPRetry) WithClock(c Clock) *HTTPRetry {
+	h.clock = c
+
+	return h
+}
+
+// WithFallback sets function which synthesizes response when retries are exhausted, e.g. from cache or static
+// default. Function receives [ExhaustedError] with attempts results. Nil return means no fallback. Fallback responses
+// are marked with [FallbackHeader].
+func (h *HTTPRetry) WithFallback(f func(req *http.Request, err error) *http.Response) *HTTPRetry {
+	h.fallback = f
+
+	return h
+}
+
+// WithOnRetry sets hook called before pause between attempts. Event contains number and result of failed attempt
+// and pause before next attempt.
+func (h *HTTPRetry) WithOnRetry(f func(Event)) *HTTPRetry {
+	h.onRetry = f
+
+	return h
+}
+
+// WithOnGiveUp sets hook called when failed request won't be retried anymore because of exhausted limits, retry
+// policy or not replayable body. Event contains number and result of the last attempt.
+func (h *HTTPRetry) WithOnGiveUp(f func(Event)) *HTTPRetry {
+	h.onGiveUp = f
+
+	return h
+}
+
+// WithAttemptHeader sets header name for attempt number of retried requests. Empty name disables header.
+// See [AttemptHeader].
+func (h *HTTPRetry) WithAttemptHeader(name string) *HTTPRetry {
+	h.attemptHeader = name
+
+	return h
+}
+
 // WithErrLogger sets logger for response errors.
 func (h *HTTPRetry) WithErrLogger(log logger) *HTTPRetry {
 	h.log = log
@@ -125,31 +253,52 @@ func (h *HTTPRetry) WithErrLogger(log logger) *HTTPRetry {
 	return h
 }
 
-func (h *HTTPRetry) checkLimit() bool {
-	if h.current <= uint(h.limit) || (h.limit < 0 && h.current <= math.MaxUint) {
-		return true
+// pause returns pause requested by server in previous response or calculated by backoff strategy.
+func (h *HTTPRetry) pause(res *http.Response, retry uint, last time.Duration) time.Duration {
+	if h.maxRetryAfter > 0 {
+		if p, ok := serverPause(res, h.clock.Now()); ok {
+			return min(p, h.maxRetryAfter)
+		}
+	}
+
+	return h.backoff.Pause(retry, last)
+}
+
+// deadline returns time limit for request retries or zero time if there is no limit.
+func (h *HTTPRetry) deadline() time.Time {
+	if h.maxElapsed <= 0 {
+		return time.Time{}
+	}
+
+	return h.clock.Now().Add(h.maxElapsed)
+}
+
+// doRequest makes single request attempt. Returned cancel function must be called after response processing.
+func (h *HTTPRetry) doRequest(
+	ctx context.Context,
+	req *http.Request,
+	body io.ReadCloser,
+	attempt uint,
+) (*http.Response, func(), error) {
+	var cancel context.CancelFunc
+
+	ctx = context.WithValue(ctx, attemptKey{}, attempt)
+
+	if h.timeout != 0 {
+		ctx, cancel = h.clock.WithTimeout(ctx, h.timeout)
+	}
+
+	req = req.Clone(ctx)
+	req.Body = body
+
+	if h.attemptHeader != "" && attempt > 1 {
+		req.Header.Set(h.attemptHeader, strconv.FormatUint(uint64(attempt), 10))
 	}
 
-	return false
-}
-
-func (h *HTTPRetry) doRequest(req *http.Request) (*http.Response, error) {
-	switch {
-	case h.ctx == nil && h.timeout == 0:
-		req = req.Clone(context.Background())
-	case h.ctx == nil && h.timeout != 0:
-		ctx, cancel := context.WithTimeout(context.Background(), h.timeout)
-		defer cancel()
-		req = req.Clone(ctx)
-	case h.ctx != nil && h.timeout != 0:
-		ctx, cancel := context.WithTimeout(h.ctx, h.timeout)
-		defer cancel()
-		req = req.Clone(ctx)
-	case h.ctx != nil && h.timeout == 0:
-		ctx, cancel := context.WithCancel(h.ctx)
-		defer cancel()
-		req = req.Clone(ctx)
+	res, err := h.next.RoundTrip(req)
+	if res != nil && res.Request == nil {
+		res.Request = req
 	}
 
-	return h.next.RoundTrip(req)
+	return res, cancel, err
 }
@@ -6,6 +6,8 @@ import (
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"sync"
 	"time"
 
 	"go.uber.org/mock/gomock"
@@ -23,8 +25,10 @@ const (
 	unexpectedResults  = "Unexpected log results"
 	unexpectedResponse = "Unexpected response"
 	unexpectedError    = "Unexpected error"
+	unexpectedAttempts = "Unexpected attempts count"
 	URL                = "https://localhost"
 	count              = 4
+	concurrency        = 20
 )
 
 type roundTripMultipleCase struct {
@@ -143,7 +147,7 @@ func (s *suite) TestRoundTripOnce() {
 				return
 			}
 
-			s.Require().Error(c.expectedErr)
+			s.Require().ErrorIs(err, c.expectedErr, unexpectedError)
 		})
 
 		if c.expectedRes != nil {
@@ -152,6 +156,62 @@ func (s *suite) TestRoundTripOnce() {
 	}
 }
 
+func (s *suite) TestRoundTripConcurrent() {
+	var seen sync.Map
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, loaded := seen.LoadOrStore(r.URL.Query().Get("id"), struct{}{}); !loaded {
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	r := New(srv.Client().Transport).
+		WithPause(time.Millisecond).
+		WithLimit(2)
+	c := http.Client{Transport: r}
+
+	var wg sync.WaitGroup
+
+	attempts := make([]uint, concurrency)
+	statuses := make([]int, concurrency)
+
+	for i := range concurrency {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			res, err := c.Get(srv.URL + "?id=" + strconv.Itoa(i))
+			if err != nil {
+				return
+			}
+
+			_ = res.Body.Close()
+			attempts[i] = Attempts(res)
+			statuses[i] = res.StatusCode
+		}()
+	}
+
+	wg.Wait()
+
+	for i := range concurrency {
+		s.Equal(uint(2), attempts[i], unexpectedAttempts)
+		s.Equal(http.StatusOK, statuses[i], unexpectedResponse)
+	}
+
+	s.Equal(uint(2*concurrency), r.Count(), unexpectedRecCount)
+}
+
+func (s *suite) TestAttemptsWithoutRetry() {
+	s.Zero(Attempts(nil), unexpectedAttempts)
+	s.Zero(Attempts(&http.Response{}), unexpectedAttempts)
+}
+
 func roundTripMultipleProvider() []roundTripMultipleCase {
 	reqBody := []byte(`{"name":"Boris", "age": 20}`)
 	request, _ := http.NewRequest(http.MethodPost, URL, bytes.NewBuffer(reqBody))
@@ -250,7 +310,7 @@ func roundTripOnceProvider() []roundTripOnceCase {
 				Server:      "192.168.1.100",
 				IsTimeout:   false,
 				IsTemporary: false,
-				IsNotFound:  false,
+				IsNotFound:  true,
 			},
 			expected: []observer.LoggedEntry{{
 				Entry:   zapcore.Entry{Level: zap.ErrorLevel, Message: "lookup host on 192.168.1.100: not found"},
@@ -0,0 +1,130 @@
+// Package retrytest provides utilities for HTTP retry testing.
+package retrytest
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Clock is a fake clock for deterministic tests. Sleep advances time instantly and records pause, so backoff
+// schedules can be asserted without real waiting. Timeouts expire when time is advanced past their deadlines.
+// Clock is safe for concurrent use.
+type Clock struct {
+	now    time.Time
+	timers []*timer
+	sleeps []time.Duration
+	mu     sync.Mutex
+}
+
+// timer holds function called when time reaches deadline.
+type timer struct {
+	at   time.Time
+	fire func()
+}
+
+// NewClock creates fake clock with given current time.
+func NewClock(now time.Time) *Clock {
+	return &Clock{
+		now: now,
+	}
+}
+
+// Now returns fake current time.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// Sleep records pause and advances time by it. Returns context error when context is done.
+func (c *Clock) Sleep(ctx context.Context, d time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if d <= 0 {
+		return nil
+	}
+
+	c.mu.Lock()
+	c.sleeps = append(c.sleeps, d)
+	c.mu.Unlock()
+
+	c.Advance(d)
+
+	return ctx.Err()
+}
+
+// WithTimeout returns context which is canceled with [context.DeadlineExceeded] error when time is advanced past
+// timeout.
+func (c *Clock) WithTimeout(parent context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	ctx := newDeadlineContext(parent, c.Now().Add(d))
+
+	if d <= 0 {
+		ctx.cancel(context.DeadlineExceeded)
+
+		return ctx, func() {
+			ctx.cancel(context.Canceled)
+		}
+	}
+
+	t := &timer{
+		at: ctx.deadline,
+		fire: func() {
+			ctx.cancel(context.DeadlineExceeded)
+		},
+	}
+
+	c.mu.Lock()
+	c.timers = append(c.timers, t)
+	c.mu.Unlock()
+
+	return ctx, func() {
+		c.remove(t)
+		ctx.cancel(context.Canceled)
+	}
+}
+
+// Advance moves time forward and expires timeouts with reached deadlines.
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+
+	var expired []*timer
+
+	c.timers = slices.DeleteFunc(c.timers, func(t *timer) bool {
+		if t.at.After(c.now) {
+			return false
+		}
+
+		expired = append(expired, t)
+
+		return true
+	})
+	c.mu.Unlock()
+
+	for _, t := range expired {
+		t.fire()
+	}
+}
+
+// Sleeps returns recorded pauses.
+func (c *Clock) Sleeps() []time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return slices.Clone(c.sleeps)
+}
+
+// remove deletes timer.
+func (c *Clock) remove(t *timer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.timers = slices.DeleteFunc(c.timers, func(v *timer) bool {
+		return v == t
+	})
+}
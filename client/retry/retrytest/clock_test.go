@@ -0,0 +1,83 @@
+package retrytest
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	unexpectedError  = "Unexpected error"
+	unexpectedTime   = "Unexpected time"
+	unexpectedSleeps = "Unexpected sleeps"
+	second           = time.Second
+)
+
+func (s *suite) TestSleep() {
+	start := time.Unix(0, 0)
+	c := NewClock(start)
+
+	s.Require().NoError(c.Sleep(context.Background(), second), unexpectedError)
+	s.Require().NoError(c.Sleep(context.Background(), 0), unexpectedError)
+	s.Require().NoError(c.Sleep(context.Background(), 2*second), unexpectedError)
+
+	s.Equal(start.Add(3*second), c.Now(), unexpectedTime)
+	s.Equal([]time.Duration{second, 2 * second}, c.Sleeps(), unexpectedSleeps)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	s.Require().ErrorIs(c.Sleep(ctx, second), context.Canceled, unexpectedError)
+	s.Equal(start.Add(3*second), c.Now(), unexpectedTime)
+}
+
+func (s *suite) TestSleepTimeout() {
+	c := NewClock(time.Unix(0, 0))
+
+	ctx, cancel := c.WithTimeout(context.Background(), second)
+	defer cancel()
+
+	s.Require().ErrorIs(c.Sleep(ctx, 2*second), context.DeadlineExceeded, unexpectedError)
+}
+
+func (s *suite) TestWithTimeout() {
+	start := time.Unix(0, 0)
+	c := NewClock(start)
+
+	ctx, cancel := c.WithTimeout(context.Background(), 2*second)
+	defer cancel()
+
+	deadline, ok := ctx.Deadline()
+	s.True(ok, unexpectedTime)
+	s.Equal(start.Add(2*second), deadline, unexpectedTime)
+
+	c.Advance(second)
+	s.Require().NoError(ctx.Err(), unexpectedError)
+
+	c.Advance(second)
+	<-ctx.Done()
+	s.Require().ErrorIs(ctx.Err(), context.DeadlineExceeded, unexpectedError)
+}
+
+func (s *suite) TestWithTimeoutCancel() {
+	c := NewClock(time.Unix(0, 0))
+
+	ctx, cancel := c.WithTimeout(context.Background(), second)
+	cancel()
+	cancel()
+
+	s.Require().ErrorIs(ctx.Err(), context.Canceled, unexpectedError)
+	s.Empty(c.timers, unexpectedError)
+
+	c.Advance(second)
+	s.Require().ErrorIs(ctx.Err(), context.Canceled, unexpectedError)
+}
+
+func (s *suite) TestWithZeroTimeout() {
+	c := NewClock(time.Unix(0, 0))
+
+	ctx, cancel := c.WithTimeout(context.Background(), 0)
+	defer cancel()
+
+	s.Require().ErrorIs(ctx.Err(), context.DeadlineExceeded, unexpectedError)
+	s.Empty(c.timers, unexpectedError)
+}
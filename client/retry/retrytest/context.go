@@ -0,0 +1,67 @@
+package retrytest
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// deadlineContext is a context with deadline controlled by fake [Clock].
+type deadlineContext struct {
+	context.Context
+
+	deadline time.Time
+	err      error
+	done     chan struct{}
+	stop     func() bool
+	mu       sync.Mutex
+}
+
+// newDeadlineContext creates context which is also canceled with parent.
+func newDeadlineContext(parent context.Context, deadline time.Time) *deadlineContext {
+	ctx := &deadlineContext{
+		Context:  parent,
+		deadline: deadline,
+		done:     make(chan struct{}),
+	}
+
+	ctx.mu.Lock()
+	ctx.stop = context.AfterFunc(parent, func() {
+		ctx.cancel(parent.Err())
+	})
+	ctx.mu.Unlock()
+
+	return ctx
+}
+
+// Deadline returns fake deadline.
+func (c *deadlineContext) Deadline() (time.Time, bool) {
+	return c.deadline, true
+}
+
+// Done returns channel which is closed on context cancellation.
+func (c *deadlineContext) Done() <-chan struct{} {
+	return c.done
+}
+
+// Err returns cancellation error.
+func (c *deadlineContext) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.err
+}
+
+// cancel closes context with error. Subsequent calls do nothing.
+func (c *deadlineContext) cancel(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.err != nil {
+		return
+	}
+
+	c.err = err
+	close(c.done)
+	c.stop()
+}
@@ -0,0 +1,31 @@
+package retrytest
+
+import (
+	"context"
+	"time"
+)
+
+type key struct{}
+
+func (s *suite) TestParentCancel() {
+	parent, cancel := context.WithCancel(context.WithValue(context.Background(), key{}, "value"))
+	ctx := newDeadlineContext(parent, time.Unix(0, 0))
+
+	s.Equal("value", ctx.Value(key{}), unexpectedError)
+	s.Require().NoError(ctx.Err(), unexpectedError)
+
+	cancel()
+	<-ctx.Done()
+
+	s.Require().ErrorIs(ctx.Err(), context.Canceled, unexpectedError)
+}
+
+func (s *suite) TestCanceledParent() {
+	parent, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	ctx := newDeadlineContext(parent, time.Unix(0, 0))
+	<-ctx.Done()
+
+	s.Require().ErrorIs(ctx.Err(), context.Canceled, unexpectedError)
+}
@@ -0,0 +1,100 @@
+package retry
+
+import (
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+)
+
+// Rule describes requests which are routed to policy. Empty fields match any request.
+type Rule struct {
+	Host        string   // Host name without port. Leading "*." matches any subdomain, e.g. "*.example.com".
+	PathPrefix  string   // URL path prefix, e.g. "/api/".
+	PathPattern string   // URL path pattern in [path.Match] syntax, e.g. "/users/*/orders". Invalid never matches.
+	Methods     []string // HTTP methods, e.g. [http.MethodGet]. Empty method of request is treated as GET.
+}
+
+// route binds rule to policy.
+type route struct {
+	policy http.RoundTripper
+	rule   Rule
+}
+
+// Router selects retry policy for request by its host, path and method. Policy of the first matching rule is used,
+// fallback policy is used when there are no matching rules.
+type Router struct {
+	fallback http.RoundTripper
+	routes   []route
+}
+
+// NewRouter creates retry policy router with fallback policy.
+func NewRouter(fallback http.RoundTripper) *Router {
+	return &Router{
+		fallback: fallback,
+	}
+}
+
+// RoundTrip [http.RoundTripper] implementation.
+func (r *Router) RoundTrip(req *http.Request) (*http.Response, error) {
+	for _, rt := range r.routes {
+		if rt.rule.match(req) {
+			return rt.policy.RoundTrip(req)
+		}
+	}
+
+	return r.fallback.RoundTrip(req)
+}
+
+// WithRoute adds rule with policy. Policy is usually [HTTPRetry] instance, but any [http.RoundTripper] can be used,
+// e.g. underlying transport for requests which mustn't be retried.
+func (r *Router) WithRoute(rule Rule, policy http.RoundTripper) *Router {
+	r.routes = append(r.routes, route{
+		policy: policy,
+		rule:   rule,
+	})
+
+	return r
+}
+
+// match reports whether request matches rule.
+func (r *Rule) match(req *http.Request) bool {
+	return r.matchHost(req.URL.Hostname()) && r.matchPath(req.URL.Path) && r.matchMethod(req.Method)
+}
+
+// matchHost reports whether host matches rule.
+func (r *Rule) matchHost(host string) bool {
+	if r.Host == "" {
+		return true
+	}
+
+	if suffix, ok := strings.CutPrefix(r.Host, "*"); ok {
+		return len(host) > len(suffix) && strings.HasSuffix(strings.ToLower(host), strings.ToLower(suffix))
+	}
+
+	return strings.EqualFold(r.Host, host)
+}
+
+// matchPath reports whether URL path matches rule.
+func (r *Rule) matchPath(p string) bool {
+	if !strings.HasPrefix(p, r.PathPrefix) {
+		return false
+	}
+
+	if r.PathPattern == "" {
+		return true
+	}
+
+	ok, err := path.Match(r.PathPattern, p)
+
+	return err == nil && ok
+}
+
+// matchMethod reports whether method matches rule.
+func (r *Rule) matchMethod(method string) bool {
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	return len(r.Methods) == 0 || slices.Contains(r.Methods, method)
+}
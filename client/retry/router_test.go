@@ -0,0 +1,108 @@
+package retry
+
+import (
+	"net/http"
+	"net/http/httptest"
+)
+
+const (
+	unexpectedPolicy = "Unexpected policy"
+	policyHeader     = "X-Policy"
+)
+
+// policy is a round tripper which marks responses by its name.
+type policy string
+
+// RoundTrip [http.RoundTripper] implementation.
+func (p policy) RoundTrip(_ *http.Request) (*http.Response, error) {
+	rec := httptest.NewRecorder()
+	rec.Header().Set(policyHeader, string(p))
+
+	return rec.Result(), nil
+}
+
+type routerCase struct {
+	name     string
+	method   string
+	url      string
+	expected string
+}
+
+func (s *suite) TestRouter() {
+	r := NewRouter(policy("default")).
+		WithRoute(Rule{Host: "payments.example.com"}, policy("none")).
+		WithRoute(Rule{Host: "*.svc.local", Methods: []string{http.MethodGet, http.MethodHead}}, policy("internal")).
+		WithRoute(Rule{Host: "api.example.com", PathPattern: "/users/*/orders"}, policy("orders")).
+		WithRoute(Rule{PathPrefix: "/api/", PathPattern: "[invalid"}, policy("invalid")).
+		WithRoute(Rule{PathPrefix: "/api/"}, policy("api"))
+
+	for _, c := range routerProvider() {
+		s.Run(c.name, func() {
+			req, _ := http.NewRequest(http.MethodGet, c.url, nil)
+			req.Method = c.method
+
+			res, err := r.RoundTrip(req)
+
+			s.Require().NoError(err, unexpectedError)
+			s.Equal(c.expected, res.Header.Get(policyHeader), unexpectedPolicy)
+		})
+	}
+}
+
+func routerProvider() []routerCase {
+	return []routerCase{
+		{
+			name:     "exact host",
+			method:   http.MethodPost,
+			url:      "https://Payments.Example.com:8443/charges",
+			expected: "none",
+		},
+		{
+			name:     "wildcard host",
+			method:   http.MethodGet,
+			url:      "http://users.svc.local/users",
+			expected: "internal",
+		},
+		{
+			name:     "wildcard host without subdomain",
+			method:   http.MethodGet,
+			url:      "http://svc.local/users",
+			expected: "default",
+		},
+		{
+			name:     "empty method",
+			url:      "http://users.svc.local/users",
+			expected: "internal",
+		},
+		{
+			name:     "not matching method",
+			method:   http.MethodPost,
+			url:      "http://users.svc.local/users",
+			expected: "default",
+		},
+		{
+			name:     "path pattern",
+			method:   http.MethodGet,
+			url:      "https://api.example.com/users/42/orders",
+			expected: "orders",
+		},
+		{
+			name:     "not matching path pattern",
+			method:   http.MethodGet,
+			url:      "https://api.example.com/users/42/orders/1",
+			expected: "default",
+		},
+		{
+			name:     "path prefix",
+			method:   http.MethodPut,
+			url:      "https://example.com/api/users",
+			expected: "api",
+		},
+		{
+			name:     "fallback",
+			method:   http.MethodGet,
+			url:      "https://example.com/users",
+			expected: "default",
+		},
+	}
+}
@@ -0,0 +1,37 @@
+package retry
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Stats is a snapshot of [HTTPRetry] counters.
+type Stats struct {
+	Requests       uint64        // Requests processed.
+	Attempts       uint64        // Request attempts made.
+	StatusRetries  uint64        // Retries caused by responses.
+	NetworkRetries uint64        // Retries caused by transport errors.
+	GiveUps        uint64        // Failed requests which weren't retried anymore.
+	Pause          time.Duration // Cumulative pause between attempts.
+}
+
+// stats holds [HTTPRetry] counters.
+type stats struct {
+	requests       atomic.Uint64
+	statusRetries  atomic.Uint64
+	networkRetries atomic.Uint64
+	giveUps        atomic.Uint64
+	pause          atomic.Int64
+}
+
+// Stats returns snapshot of counters for all requests made by instance. It is safe for concurrent use.
+func (h *HTTPRetry) Stats() Stats {
+	return Stats{
+		Requests:       h.stats.requests.Load(),
+		Attempts:       h.done.Load(),
+		StatusRetries:  h.stats.statusRetries.Load(),
+		NetworkRetries: h.stats.networkRetries.Load(),
+		GiveUps:        h.stats.giveUps.Load(),
+		Pause:          time.Duration(h.stats.pause.Load()),
+	}
+}
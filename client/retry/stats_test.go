@@ -0,0 +1,104 @@
+package retry
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/nafigator/http/client/retry/retrytest"
+)
+
+const unexpectedStats = "Unexpected stats"
+
+func (s *suite) TestStats() {
+	ctrl := gomock.NewController(s.T())
+	next := NewMockRoundTripper(ctrl)
+	clock := retrytest.NewClock(time.Unix(0, 0))
+
+	r := New(next).
+		WithPause(time.Second).
+		WithLimit(count).
+		WithClock(clock)
+
+	s.Equal(Stats{}, r.Stats(), unexpectedStats)
+
+	unavailable := func(_ *http.Request) (*http.Response, error) {
+		rec := httptest.NewRecorder()
+		rec.WriteHeader(http.StatusServiceUnavailable)
+
+		return rec.Result(), nil
+	}
+
+	gomock.InOrder(
+		next.EXPECT().RoundTrip(gomock.Any()).DoAndReturn(unavailable),
+		next.EXPECT().RoundTrip(gomock.Any()).Return(nil, io.EOF),
+		next.EXPECT().RoundTrip(gomock.Any()).Return(httptest.NewRecorder().Result(), nil),
+		next.EXPECT().RoundTrip(gomock.Any()).DoAndReturn(unavailable).Times(count),
+	)
+
+	for range 2 {
+		request, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, URL, nil)
+
+		res, err := r.RoundTrip(request)
+		s.Require().NoError(err, unexpectedError)
+		s.Require().NoError(res.Body.Close(), unexpectedError)
+	}
+
+	expected := Stats{
+		Requests:       2,
+		Attempts:       3 + count,
+		StatusRetries:  1 + count - 1,
+		NetworkRetries: 1,
+		GiveUps:        1,
+		Pause:          (2 + count - 1) * time.Second,
+	}
+
+	s.Equal(expected, r.Stats(), unexpectedStats)
+}
+
+func (s *suite) TestStatsConcurrent() {
+	ctrl := gomock.NewController(s.T())
+	next := NewMockRoundTripper(ctrl)
+
+	r := New(next).
+		WithPause(time.Second).
+		WithLimit(2).
+		WithClock(retrytest.NewClock(time.Unix(0, 0)))
+
+	next.EXPECT().
+		RoundTrip(gomock.Any()).
+		Return(nil, io.EOF).
+		Times(concurrency * 2)
+
+	var wg sync.WaitGroup
+
+	for range concurrency {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			request, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, URL, nil)
+			_, _ = r.RoundTrip(request)
+
+			_ = r.Stats()
+		}()
+	}
+
+	wg.Wait()
+
+	expected := Stats{
+		Requests:       concurrency,
+		Attempts:       concurrency * 2,
+		NetworkRetries: concurrency,
+		GiveUps:        concurrency,
+		Pause:          concurrency * time.Second,
+	}
+
+	s.Equal(expected, r.Stats(), unexpectedStats)
+}
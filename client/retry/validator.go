@@ -0,0 +1,94 @@
+package retry
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"syscall"
+)
+
+// Validator checks result of request attempt. Retry on false return.
+type Validator func(res *http.Response, err error) bool
+
+// IsRetryableError reports whether transport error is temporary: timeouts, connection resets and refusals, EOF on
+// idle connections, HTTP/2 GOAWAY and DNS failures except not found hosts. TLS verification failures, canceled
+// requests and unknown errors are considered permanent.
+func IsRetryableError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var certErr *tls.CertificateVerificationError
+	var authorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var invalidErr x509.CertificateInvalidError
+	var dnsErr *net.DNSError
+	var netErr net.Error
+
+	switch {
+	case errors.As(err, &certErr), errors.As(err, &authorityErr), errors.As(err, &hostnameErr),
+		errors.As(err, &invalidErr):
+		return false
+	case errors.As(err, &dnsErr):
+		return !dnsErr.IsNotFound
+	case errors.Is(err, context.DeadlineExceeded),
+		errors.Is(err, io.EOF),
+		errors.Is(err, io.ErrUnexpectedEOF),
+		errors.Is(err, syscall.ECONNRESET),
+		errors.Is(err, syscall.ECONNREFUSED),
+		errors.Is(err, syscall.ECONNABORTED),
+		errors.Is(err, syscall.EPIPE),
+		errors.As(err, &netErr) && netErr.Timeout():
+		return true
+	}
+
+	msg := err.Error()
+
+	return strings.Contains(msg, "GOAWAY") ||
+		strings.Contains(msg, "server closed idle connection") ||
+		strings.Contains(msg, "client connection lost")
+}
+
+// ValidateNetwork validator requires retry on temporary transport errors. See [IsRetryableError].
+func ValidateNetwork(_ *http.Response, err error) bool {
+	return !IsRetryableError(err)
+}
+
+// ValidateStatus returns validator which requires retry on responses with listed status codes.
+func ValidateStatus(codes ...int) Validator {
+	return func(res *http.Response, _ error) bool {
+		return res == nil || !slices.Contains(codes, res.StatusCode)
+	}
+}
+
+// ValidateAll returns validator which requires retry when any of validators requires it.
+func ValidateAll(validators ...Validator) Validator {
+	return func(res *http.Response, err error) bool {
+		for _, v := range validators {
+			if !v(res, err) {
+				return false
+			}
+		}
+
+		return true
+	}
+}
+
+// ValidateDefault validator requires retry on 429, 502, 503 and 504 responses and temporary transport errors.
+func ValidateDefault(res *http.Response, err error) bool {
+	return ValidateAll(
+		ValidateStatus(
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		),
+		ValidateNetwork,
+	)(res, err)
+}
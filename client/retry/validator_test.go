@@ -0,0 +1,248 @@
+package retry
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"syscall"
+)
+
+const unexpectedClass = "Unexpected error classification"
+
+type retryableErrorCase struct {
+	err      error
+	name     string
+	expected bool
+}
+
+type validatorCase struct {
+	validator Validator
+	err       error
+	response  *http.Response
+	name      string
+	expected  bool
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func (s *suite) TestIsRetryableError() {
+	for _, c := range retryableErrorProvider() {
+		s.Run(c.name, func() {
+			s.Equal(c.expected, IsRetryableError(c.err), unexpectedClass)
+		})
+	}
+}
+
+func (s *suite) TestValidators() {
+	for _, c := range validatorProvider() {
+		s.Run(c.name, func() {
+			s.Equal(c.expected, c.validator(c.response, c.err), unexpectedClass)
+		})
+	}
+}
+
+func (s *suite) TestNetworkErrorRetry() {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	s.Require().NoError(err, unexpectedError)
+
+	addr := ln.Addr().String()
+	_ = ln.Close()
+
+	r := New(http.DefaultTransport).
+		WithPause(0).
+		WithLimit(count).
+		WithExhaustedError(true)
+
+	request, _ := http.NewRequest(http.MethodGet, "http://"+addr, nil)
+	res, err := r.RoundTrip(request)
+
+	var exhausted *ExhaustedError
+
+	s.Nil(res, unexpectedResponse)
+	s.Require().ErrorAs(err, &exhausted, unexpectedError)
+	s.Require().ErrorIs(err, syscall.ECONNREFUSED, unexpectedError)
+	s.Len(exhausted.Attempts, count, unexpectedAttempts)
+}
+
+func (s *suite) TestTLSErrorNoRetry() {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	srv.Config.ErrorLog = slog.NewLogLogger(slog.NewTextHandler(io.Discard, nil), slog.LevelError)
+	srv.StartTLS()
+	defer srv.Close()
+
+	r := New(http.DefaultTransport).
+		WithPause(0).
+		WithLimit(count)
+
+	request, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	res, err := r.RoundTrip(request)
+
+	var certErr *tls.CertificateVerificationError
+
+	s.Nil(res, unexpectedResponse)
+	s.Require().ErrorAs(err, &certErr, unexpectedError)
+	s.Equal(uint(1), r.Count(), unexpectedRecCount)
+}
+
+func retryableErrorProvider() []retryableErrorCase {
+	return []retryableErrorCase{
+		{
+			name: "nil error",
+		},
+		{
+			name: "canceled",
+			err:  context.Canceled,
+		},
+		{
+			name:     "deadline exceeded",
+			err:      context.DeadlineExceeded,
+			expected: true,
+		},
+		{
+			name:     "timeout",
+			err:      &net.OpError{Op: "dial", Net: "tcp", Err: timeoutError{}},
+			expected: true,
+		},
+		{
+			name: "connection refused",
+			err: &net.OpError{Op: "dial", Net: "tcp", Err: &os.SyscallError{
+				Syscall: "connect",
+				Err:     syscall.ECONNREFUSED,
+			}},
+			expected: true,
+		},
+		{
+			name:     "connection reset",
+			err:      &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET},
+			expected: true,
+		},
+		{
+			name:     "EOF",
+			err:      fmt.Errorf("read response: %w", io.EOF),
+			expected: true,
+		},
+		{
+			name:     "unexpected EOF",
+			err:      io.ErrUnexpectedEOF,
+			expected: true,
+		},
+		{
+			name:     "HTTP/2 GOAWAY",
+			err:      errors.New("http2: server sent GOAWAY and closed the connection; LastStreamID=1, ErrCode=NO_ERROR"),
+			expected: true,
+		},
+		{
+			name:     "server closed idle connection",
+			err:      errors.New("http: server closed idle connection"),
+			expected: true,
+		},
+		{
+			name:     "DNS temporary failure",
+			err:      &net.DNSError{Err: "server misbehaving", Name: "host", IsTemporary: true},
+			expected: true,
+		},
+		{
+			name: "DNS not found",
+			err:  &net.DNSError{Err: "no such host", Name: "host", IsNotFound: true},
+		},
+		{
+			name: "TLS verification failure",
+			err:  &tls.CertificateVerificationError{Err: x509.UnknownAuthorityError{}},
+		},
+		{
+			name: "TLS hostname mismatch",
+			err:  x509.HostnameError{Host: "localhost", Certificate: &x509.Certificate{}},
+		},
+		{
+			name: "TLS invalid certificate",
+			err:  x509.CertificateInvalidError{Reason: x509.Expired, Cert: &x509.Certificate{}},
+		},
+		{
+			name: "invalid URL",
+			err:  &url.Error{Op: "parse", URL: "::", Err: errors.New("missing protocol scheme")},
+		},
+	}
+}
+
+func validatorProvider() []validatorCase {
+	response := func(code int) *http.Response {
+		return &http.Response{StatusCode: code}
+	}
+
+	return []validatorCase{
+		{
+			name:      "status valid",
+			validator: ValidateStatus(http.StatusBadGateway),
+			response:  response(http.StatusOK),
+			expected:  true,
+		},
+		{
+			name:      "status retry",
+			validator: ValidateStatus(http.StatusBadGateway),
+			response:  response(http.StatusBadGateway),
+		},
+		{
+			name:      "status without response",
+			validator: ValidateStatus(http.StatusBadGateway),
+			expected:  true,
+		},
+		{
+			name:      "network valid",
+			validator: ValidateNetwork,
+			err:       errors.New("permanent"),
+			expected:  true,
+		},
+		{
+			name:      "network retry",
+			validator: ValidateNetwork,
+			err:       io.EOF,
+		},
+		{
+			name:      "all valid",
+			validator: ValidateAll(ValidateStatus(http.StatusBadGateway), ValidateNetwork),
+			response:  response(http.StatusOK),
+			expected:  true,
+		},
+		{
+			name:      "all retry",
+			validator: ValidateAll(ValidateStatus(http.StatusBadGateway), ValidateNetwork),
+			err:       io.EOF,
+		},
+		{
+			name:      "default too many requests",
+			validator: ValidateDefault,
+			response:  response(http.StatusTooManyRequests),
+		},
+		{
+			name:      "default gateway timeout",
+			validator: ValidateDefault,
+			response:  response(http.StatusGatewayTimeout),
+		},
+		{
+			name:      "default internal error",
+			validator: ValidateDefault,
+			response:  response(http.StatusInternalServerError),
+			expected:  true,
+		},
+		{
+			name:      "default connection reset",
+			validator: ValidateDefault,
+			err:       syscall.ECONNRESET,
+		},
+	}
+}
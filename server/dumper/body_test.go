@@ -0,0 +1,186 @@
+package dumper
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing/iotest"
+
+	"bou.ke/monkey"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/nafigator/http/headers"
+	"github.com/nafigator/http/mime"
+)
+
+const (
+	longBody = `{"name":"Boris","age":20,"city":"Moscow"}`
+)
+
+type bodyLimitCase struct {
+	request          func() *http.Request
+	name             string
+	response         string
+	expectedRequest  string
+	expectedResponse string
+	expectedLog      string
+	expectedReceived string
+	expectedSizes    [2]int64
+	usePatch         patch
+}
+
+func (s *suite) TestBodyLimit() {
+	for _, c := range bodyLimitProvider() {
+		s.Run(c.name, func() {
+			ctrl := gomock.NewController(s.T())
+			next := NewMockHandler(ctrl)
+			rec := &recorder{}
+			ob, logs := observer.New(zap.ErrorLevel)
+
+			d := New(nil).
+				WithRecordFlusher(rec).
+				WithErrLogger(zap.New(ob).Sugar()).
+				WithBodyLimit(10)
+
+			req := c.request()
+			received := ""
+
+			next.EXPECT().
+				ServeHTTP(gomock.Any(), req).
+				Do(func(w http.ResponseWriter, r *http.Request) {
+					b, _ := io.ReadAll(r.Body)
+					received = string(b)
+
+					w.Header().Set(headers.ContentType, mime.JSON)
+					_, _ = w.Write([]byte(c.response))
+				}).
+				Times(1)
+
+			if c.usePatch > 0 {
+				applyPatch(c.usePatch)
+				defer func() {
+					monkey.UnpatchAll()
+				}()
+			}
+
+			w := httptest.NewRecorder()
+			d.MiddleWare(next).ServeHTTP(w, req)
+
+			s.Require().Len(rec.records, 1, unexpectedMsgCount)
+			r := rec.records[0]
+			text := Text("%s\n\n%s")(r)
+
+			s.Contains(text, c.expectedRequest+"\n\n", unexpectedResults)
+			s.True(strings.HasSuffix(text, c.expectedResponse), unexpectedResults)
+			s.NotContains(string(r.RequestBody)+string(r.ResponseBody), "truncated", unexpectedBody)
+			s.Equal(c.expectedSizes, [2]int64{r.RequestBodySize, r.ResponseBodySize}, unexpectedBody)
+			s.Equal(c.expectedReceived, received, unexpectedBody)
+			s.Equal(c.response, w.Body.String(), unexpectedBody)
+
+			if c.expectedLog != "" {
+				s.Require().Equal(1, logs.Len(), unexpectedMsgCount)
+				s.Equal(c.expectedLog, logs.All()[0].Message, unexpectedMessage)
+			}
+		})
+	}
+}
+
+func (s *suite) TestBufferBody() {
+	length, err := bufferBody(&http.Response{})
+
+	s.Require().NoError(err, unexpectedError)
+	s.Zero(length, unexpectedBody)
+
+	_, _, err = New(nil).WithBodyLimit(10).dumpResponse(&http.Response{
+		Header: http.Header{headers.ContentType: {mime.JSON}},
+		Body:   io.NopCloser(iotest.ErrReader(errors.New("boom"))),
+	})
+
+	s.Require().EqualError(err, "boom", unexpectedError)
+}
+
+func bodyLimitProvider() []bodyLimitCase {
+	post := func() *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(longBody))
+		req.Header.Set(headers.ContentType, mime.JSON)
+
+		return req
+	}
+
+	return []bodyLimitCase{
+		{
+			name:             "long bodies",
+			request:          post,
+			response:         longBody,
+			expectedRequest:  "\r\n\r\n{\"name\":\"B\n... [truncated, original length: 41 bytes]",
+			expectedResponse: "\r\n\r\n{\"name\":\"B\n... [truncated, original length: 41 bytes]",
+			expectedReceived: longBody,
+			expectedSizes:    [2]int64{41, 41},
+		},
+		{
+			name: "unknown request length",
+			request: func() *http.Request {
+				req := post()
+				req.ContentLength = -1
+
+				return req
+			},
+			response:         longBody,
+			expectedRequest:  "\r\n\r\n{\"name\":\"B\n... [truncated, original length: unknown]",
+			expectedResponse: "\r\n\r\n{\"name\":\"B\n... [truncated, original length: 41 bytes]",
+			expectedReceived: longBody,
+			expectedSizes:    [2]int64{-1, 41},
+		},
+		{
+			name: "short bodies",
+			request: func() *http.Request {
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				req.Header.Set(headers.ContentType, mime.JSON)
+
+				return req
+			},
+			response:         "{}",
+			expectedRequest:  "Content-Type: application/json\r\n\r\n",
+			expectedResponse: "Content-Length: 2\r\nContent-Type: application/json\r\n\r\n{}",
+			expectedSizes:    [2]int64{0, 2},
+		},
+		{
+			name: "read error",
+			request: func() *http.Request {
+				req := post()
+				req.Body = io.NopCloser(io.MultiReader(strings.NewReader(`{"na`), iotest.ErrReader(errors.New("boom"))))
+
+				return req
+			},
+			response:         longBody,
+			expectedResponse: "\r\n\r\n{\"name\":\"B\n... [truncated, original length: 41 bytes]",
+			expectedLog:      "HTTP request dump error: boom",
+			expectedReceived: `{"na`,
+			expectedSizes:    [2]int64{0, 41},
+		},
+		{
+			name:             "dump request error",
+			request:          post,
+			response:         longBody,
+			usePatch:         patchDumpRequest,
+			expectedResponse: "\r\n\r\n{\"name\":\"B\n... [truncated, original length: 41 bytes]",
+			expectedLog:      requestDumpErr,
+			expectedReceived: longBody,
+			expectedSizes:    [2]int64{0, 41},
+		},
+		{
+			name:             "dump response error",
+			request:          post,
+			response:         longBody,
+			usePatch:         patchDumpResponse,
+			expectedRequest:  "\r\n\r\n{\"name\":\"B\n... [truncated, original length: 41 bytes]",
+			expectedLog:      responseDumpErr,
+			expectedReceived: longBody,
+			expectedSizes:    [2]int64{41, 0},
+		},
+	}
+}
@@ -3,9 +3,9 @@ package dumper
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 	"net/http/httputil"
+	"time"
 
 	"github.com/nafigator/http/headers"
 	"github.com/nafigator/http/mime"
@@ -24,6 +24,10 @@ type flusher interface {
 	Flush(ctx context.Context, msg string)
 }
 
+type recordFlusher interface {
+	FlushRecord(ctx context.Context, r Record)
+}
+
 type logger interface {
 	Error(args ...any)
 }
@@ -31,9 +35,12 @@ type logger interface {
 type HTTPDumper struct {
 	masker   masker
 	flusher  flusher
+	recorder recordFlusher
 	log      logger
 	filter   func(string) bool
-	template string
+	render   func(Record) string
+	now      func() time.Time
+	limit    int64
 }
 
 // New creates http-dumper instance.
@@ -41,15 +48,39 @@ func New(
 	flusher flusher,
 ) *HTTPDumper {
 	return &HTTPDumper{
-		template: defaultTemplate,
-		flusher:  flusher,
-		filter:   needBody,
+		flusher: flusher,
+		filter:  needBody,
+		render:  Text(defaultTemplate),
+		now:     time.Now,
 	}
 }
 
 // WithTemplate initializes new custom output template.
 func (h *HTTPDumper) WithTemplate(t string) *HTTPDumper {
-	h.template = t
+	h.render = Text(t)
+
+	return h
+}
+
+// WithRenderer replaces text renderer that converts records into flusher messages.
+func (h *HTTPDumper) WithRenderer(r func(Record) string) *HTTPDumper {
+	h.render = r
+
+	return h
+}
+
+// WithRecordFlusher initializes flusher for structured records. When set, records are passed
+// to it instead of rendering into messages for flusher.
+func (h *HTTPDumper) WithRecordFlusher(f recordFlusher) *HTTPDumper {
+	h.recorder = f
+
+	return h
+}
+
+// WithBodyLimit sets maximum size of dumped body in bytes. Longer bodies are truncated in dumps
+// but still streamed completely. Zero means no limit.
+func (h *HTTPDumper) WithBodyLimit(n int64) *HTTPDumper {
+	h.limit = n
 
 	return h
 }
@@ -77,7 +108,7 @@ func (h *HTTPDumper) WithFilter(f func(string) bool) *HTTPDumper {
 
 func (h *HTTPDumper) MiddleWare(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		b, e := httputil.DumpRequest(r, h.filter(r.Header.Get(headers.ContentType)))
+		b, t, e := h.dumpRequest(r)
 		if e != nil {
 			if h.log != nil {
 				h.log.Error("HTTP request dump error: ", e)
@@ -85,7 +116,7 @@ func (h *HTTPDumper) MiddleWare(next http.Handler) http.Handler {
 
 			dump := ""
 
-			h.handleRequest(w, r, next, dump)
+			h.handleRequest(w, r, next, dump, truncation{})
 
 			return
 		}
@@ -95,45 +126,118 @@ func (h *HTTPDumper) MiddleWare(next http.Handler) http.Handler {
 			h.masker.Mask(r, &dump)
 		}
 
-		h.handleRequest(w, r, next, dump)
+		h.handleRequest(w, r, next, dump, t)
 	})
 }
 
-func (h *HTTPDumper) handleRequest(w http.ResponseWriter, r *http.Request, next http.Handler, reqDump string) {
+func (h *HTTPDumper) handleRequest(
+	w http.ResponseWriter,
+	r *http.Request,
+	next http.Handler,
+	reqDump string,
+	reqCut truncation,
+) {
 	ctx := r.Context()
 	ww := wrapper.New(w, r)
+	rec := Record{
+		Method:      r.Method,
+		URL:         requestURL(r),
+		RequestDump: reqDump,
+		Start:       h.now(),
+	}
+
+	if h.masker != nil {
+		h.masker.Mask(r, &rec.URL)
+	}
+
+	rec.RequestHeader, rec.RequestBody = parse(reqDump)
+	rec.RequestBodySize = reqCut.bodySize(rec.RequestBody)
+	rec.RequestTruncated = reqCut.truncated
 
 	// Process request
 	next.ServeHTTP(&ww, r)
 
+	rec.End = h.now()
 	res := ww.Result()
 	res.ProtoMinor = r.ProtoMinor
 	res.ProtoMajor = r.ProtoMajor
+	rec.Status = res.StatusCode
 	defer func() {
 		if res.Body != nil {
 			_ = res.Body.Close()
 		}
 	}()
 
-	b, e := httputil.DumpResponse(res, h.filter(res.Header.Get(headers.ContentType)))
+	b, resCut, e := h.dumpResponse(res)
 	if e != nil {
 		if h.log != nil {
 			h.log.Error("HTTP response dump error: ", e)
 		}
 
-		msg := fmt.Sprintf(h.template, reqDump, "")
-		h.flusher.Flush(ctx, msg)
+		h.flush(ctx, rec)
 
 		return
 	}
 
-	resDump := string(b)
+	rec.ResponseDump = string(b)
 	if h.masker != nil {
-		h.masker.Mask(r, &resDump)
+		h.masker.Mask(r, &rec.ResponseDump)
+	}
+
+	rec.ResponseHeader, rec.ResponseBody = parse(rec.ResponseDump)
+	rec.ResponseBodySize = resCut.bodySize(rec.ResponseBody)
+	rec.ResponseTruncated = resCut.truncated
+	h.flush(ctx, rec)
+}
+
+func (h *HTTPDumper) dumpRequest(r *http.Request) ([]byte, truncation, error) {
+	body := h.filter(r.Header.Get(headers.ContentType))
+	if !body || h.limit <= 0 {
+		b, e := httputil.DumpRequest(r, body)
+
+		return b, truncation{}, e
+	}
+
+	b, e := httputil.DumpRequest(r, false)
+	if e != nil {
+		return nil, truncation{}, e
+	}
+
+	return appendHead(b, &r.Body, r.ContentLength, h.limit)
+}
+
+func (h *HTTPDumper) dumpResponse(res *http.Response) ([]byte, truncation, error) {
+	body := h.filter(res.Header.Get(headers.ContentType))
+	if !body || h.limit <= 0 {
+		b, e := httputil.DumpResponse(res, body)
+
+		return b, truncation{}, e
+	}
+
+	length, e := bufferBody(res)
+	if e != nil {
+		return nil, truncation{}, e
+	}
+
+	head := *res
+	head.ContentLength = length
+
+	b, e := httputil.DumpResponse(&head, false)
+	if e != nil {
+		return nil, truncation{}, e
+	}
+
+	return appendHead(b, &res.Body, length, h.limit)
+}
+
+func (h *HTTPDumper) flush(ctx context.Context, rec Record) {
+	if h.recorder != nil {
+		h.recorder.FlushRecord(ctx, rec)
+
+		return
 	}
 
-	msg := fmt.Sprintf(h.template, reqDump, resDump)
-	h.flusher.Flush(ctx, msg)
+	h.flusher.Flush(ctx, h.render(rec))
 }
 
 func needBody(ct string) bool {
@@ -0,0 +1,228 @@
+package dumper
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/nafigator/http/headers"
+	"github.com/nafigator/http/mime"
+)
+
+type harCase struct {
+	name     string
+	expected string
+	record   Record
+}
+
+type queryCase struct {
+	name     string
+	url      string
+	expected []harPair
+}
+
+func (s *suite) TestHAR() {
+	for _, c := range harProvider() {
+		s.Run(c.name, func() {
+			s.JSONEq(c.expected, HAR(c.record), unexpectedMessage)
+		})
+	}
+}
+
+func (s *suite) TestHARQuery() {
+	for _, c := range queryProvider() {
+		s.Run(c.name, func() {
+			s.Equal(c.expected, harQuery(c.url), unexpectedMessage)
+		})
+	}
+}
+
+func harProvider() []harCase {
+	start := time.Date(2025, 1, 8, 9, 18, 29, 0, time.UTC)
+
+	return []harCase{
+		{
+			name: "full exchange",
+			record: Record{
+				Start:  start,
+				End:    start.Add(2 * time.Millisecond),
+				Method: http.MethodPost,
+				URL:    URL + "/login?next=%2Fhome&debug",
+				Status: http.StatusFound,
+				RequestHeader: http.Header{
+					headers.ContentType: {mime.JSON},
+					headers.Cookie:      {"session=abc"},
+				},
+				RequestBody:     []byte(`{"name":"Boris"}`),
+				RequestBodySize: 16,
+				RequestDump:     "POST /login?next=%2Fhome&debug HTTP/1.1\r\n...",
+				ResponseHeader: http.Header{
+					headers.Location:    {"/home"},
+					headers.ContentType: {mime.Bin},
+					headers.SetCookie: {
+						"token=xyz; Path=/; Domain=localhost; Expires=Wed, 08 Jan 2025 10:00:00 GMT; HttpOnly; Secure",
+					},
+				},
+				ResponseBody:     []byte{0xff, 0xfe, 0x00},
+				ResponseBodySize: 3,
+				ResponseDump:     "HTTP/1.1 302 Found\r\n...",
+			},
+			expected: `{"log": {
+				"creator": {"name": "github.com/nafigator/http/server/dumper", "version": ""},
+				"version": "1.2",
+				"entries": [{
+					"cache": {},
+					"startedDateTime": "2025-01-08T09:18:29Z",
+					"request": {
+						"postData": {"mimeType": "application/json", "text": "{\"name\":\"Boris\"}", "params": []},
+						"method": "POST",
+						"url": "https://localhost/login?next=%2Fhome&debug",
+						"httpVersion": "HTTP/1.1",
+						"cookies": [{"name": "session", "value": "abc"}],
+						"headers": [
+							{"name": "Content-Type", "value": "application/json"},
+							{"name": "Cookie", "value": "session=abc"}
+						],
+						"queryString": [{"name": "next", "value": "/home"}, {"name": "debug", "value": ""}],
+						"headersSize": -1,
+						"bodySize": 16
+					},
+					"response": {
+						"statusText": "Found",
+						"httpVersion": "HTTP/1.1",
+						"redirectURL": "/home",
+						"content": {"mimeType": "application/octet-stream", "text": "//4A", "encoding": "base64", "size": 3},
+						"cookies": [{
+							"name": "token",
+							"value": "xyz",
+							"path": "/",
+							"domain": "localhost",
+							"expires": "2025-01-08T10:00:00Z",
+							"httpOnly": true,
+							"secure": true
+						}],
+						"headers": [
+							{"name": "Content-Type", "value": "application/octet-stream"},
+							{"name": "Location", "value": "/home"},
+							{"name": "Set-Cookie", "value": "token=xyz; Path=/; Domain=localhost; Expires=Wed, 08 Jan 2025 10:00:00 GMT; HttpOnly; Secure"}
+						],
+						"status": 302,
+						"headersSize": -1,
+						"bodySize": 3
+					},
+					"timings": {"blocked": -1, "dns": -1, "connect": -1, "send": 0, "wait": 2, "receive": 0, "ssl": -1},
+					"time": 2
+				}]
+			}}`,
+		},
+		{
+			name: "empty exchange",
+			record: Record{
+				Start:  start,
+				End:    start,
+				Method: http.MethodGet,
+				URL:    URL,
+			},
+			expected: `{"log": {
+				"creator": {"name": "github.com/nafigator/http/server/dumper", "version": ""},
+				"version": "1.2",
+				"entries": [{
+					"cache": {},
+					"startedDateTime": "2025-01-08T09:18:29Z",
+					"request": {
+						"method": "GET",
+						"url": "https://localhost",
+						"httpVersion": "",
+						"cookies": [],
+						"headers": [],
+						"queryString": [],
+						"headersSize": -1,
+						"bodySize": 0
+					},
+					"response": {
+						"statusText": "",
+						"httpVersion": "",
+						"redirectURL": "",
+						"content": {"mimeType": "", "size": 0},
+						"cookies": [],
+						"headers": [],
+						"status": 0,
+						"headersSize": -1,
+						"bodySize": 0
+					},
+					"timings": {"blocked": -1, "dns": -1, "connect": -1, "send": 0, "wait": 0, "receive": 0, "ssl": -1},
+					"time": 0
+				}]
+			}}`,
+		},
+		{
+			name: "truncated bodies",
+			record: Record{
+				Start:             start,
+				End:               start,
+				Method:            http.MethodPost,
+				URL:               URL,
+				Status:            http.StatusOK,
+				RequestHeader:     http.Header{headers.ContentType: {mime.JSON}},
+				RequestBody:       []byte(`{"name":"B`),
+				RequestBodySize:   41,
+				RequestTruncated:  true,
+				ResponseHeader:    http.Header{headers.ContentType: {mime.JSON}},
+				ResponseBody:      []byte(`{"name":"B`),
+				ResponseBodySize:  -1,
+				ResponseTruncated: true,
+			},
+			expected: `{"log": {
+				"creator": {"name": "github.com/nafigator/http/server/dumper", "version": ""},
+				"version": "1.2",
+				"entries": [{
+					"cache": {},
+					"startedDateTime": "2025-01-08T09:18:29Z",
+					"request": {
+						"postData": {"mimeType": "application/json", "text": "{\"name\":\"B", "params": []},
+						"method": "POST",
+						"url": "https://localhost",
+						"httpVersion": "",
+						"cookies": [],
+						"headers": [{"name": "Content-Type", "value": "application/json"}],
+						"queryString": [],
+						"headersSize": -1,
+						"bodySize": 41
+					},
+					"response": {
+						"statusText": "OK",
+						"httpVersion": "",
+						"redirectURL": "",
+						"content": {"mimeType": "application/json", "text": "{\"name\":\"B", "size": -1},
+						"cookies": [],
+						"headers": [{"name": "Content-Type", "value": "application/json"}],
+						"status": 200,
+						"headersSize": -1,
+						"bodySize": -1
+					},
+					"timings": {"blocked": -1, "dns": -1, "connect": -1, "send": 0, "wait": 0, "receive": 0, "ssl": -1},
+					"time": 0
+				}]
+			}}`,
+		},
+	}
+}
+
+func queryProvider() []queryCase {
+	return []queryCase{
+		{
+			name:     "without query",
+			url:      URL,
+			expected: []harPair{},
+		},
+		{
+			name:     "with fragment",
+			url:      URL + "/?a=1&&b=2#top",
+			expected: []harPair{{Name: "a", Value: "1"}, {Name: "b", Value: "2"}},
+		},
+		{
+			name:     "malformed escaping",
+			url:      URL + "/?a=%zz",
+			expected: []harPair{{Name: "a", Value: "%zz"}},
+		},
+	}
+}
@@ -0,0 +1,70 @@
+package dumper
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"sync"
+)
+
+const (
+	harPrefix = `{"log":{"creator":{"name":"` + harCreatorName + `","version":""},` +
+		`"version":"` + harVersion + `","entries":[`
+	harSuffix = "]}}\n"
+)
+
+// HARLog is a record flusher that writes exchanges into HAR file. Document becomes valid after Close call.
+type HARLog struct {
+	w       io.WriteCloser
+	err     error
+	entries int
+	mu      sync.Mutex
+}
+
+// NewHARLog creates HAR file at specified path.
+func NewHARLog(path string) (*HARLog, error) {
+	f, e := os.Create(path)
+	if e != nil {
+		return nil, e
+	}
+
+	l := &HARLog{w: f}
+	l.write(harPrefix)
+
+	return l, nil
+}
+
+// FlushRecord appends record as HAR entry.
+func (l *HARLog) FlushRecord(_ context.Context, r Record) {
+	b, _ := json.Marshal(newHAREntry(r))
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.entries > 0 {
+		l.write(",")
+	}
+
+	l.write(string(b))
+	l.entries++
+}
+
+// Close finalizes HAR document and closes file. Returns first write error if any.
+func (l *HARLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.write(harSuffix)
+
+	return errors.Join(l.err, l.w.Close())
+}
+
+func (l *HARLog) write(s string) {
+	if l.err != nil {
+		return
+	}
+
+	_, l.err = io.WriteString(l.w, s)
+}
@@ -0,0 +1,54 @@
+package dumper
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	unexpectedHAR = "Unexpected HAR document"
+)
+
+func (s *suite) TestHARLog() {
+	path := filepath.Join(s.T().TempDir(), "dump.har")
+	start := time.Date(2025, 1, 8, 9, 18, 29, 0, time.UTC)
+
+	l, err := NewHARLog(path)
+	s.Require().NoError(err, unexpectedError)
+
+	for _, m := range []string{http.MethodGet, http.MethodPost} {
+		l.FlushRecord(context.Background(), Record{Start: start, End: start, Method: m, URL: URL})
+	}
+
+	s.Require().NoError(l.Close(), unexpectedError)
+
+	b, err := os.ReadFile(path)
+	s.Require().NoError(err, unexpectedError)
+
+	var doc harDocument
+	s.Require().NoError(json.Unmarshal(b, &doc), unexpectedHAR)
+
+	s.Equal(harVersion, doc.Log.Version, unexpectedHAR)
+	s.Equal(harCreatorName, doc.Log.Creator.Name, unexpectedHAR)
+	s.Require().Len(doc.Log.Entries, 2, unexpectedHAR)
+	s.Equal(http.MethodPost, doc.Log.Entries[1].Request.Method, unexpectedHAR)
+	s.Equal(newHAREntry(Record{Start: start, End: start, Method: http.MethodGet, URL: URL}), doc.Log.Entries[0],
+		unexpectedHAR)
+}
+
+func (s *suite) TestHARLogErrors() {
+	_, err := NewHARLog(filepath.Join(s.T().TempDir(), "missing", "dump.har"))
+	s.Require().Error(err, unexpectedError)
+
+	l, err := NewHARLog(filepath.Join(s.T().TempDir(), "dump.har"))
+	s.Require().NoError(err, unexpectedError)
+	s.Require().NoError(l.Close(), unexpectedError)
+
+	l.FlushRecord(context.Background(), Record{})
+
+	s.Require().ErrorIs(l.Close(), os.ErrClosed, unexpectedError)
+}
@@ -0,0 +1,109 @@
+package dumper
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/nafigator/http/headers"
+	"github.com/nafigator/http/mime"
+)
+
+type jsonCase struct {
+	name     string
+	expected string
+	record   Record
+}
+
+func (s *suite) TestJSON() {
+	for _, c := range jsonProvider() {
+		s.Run(c.name, func() {
+			s.JSONEq(c.expected, JSON(c.record), unexpectedMessage)
+			s.NotContains(JSON(c.record), "\n", unexpectedMessage)
+		})
+	}
+}
+
+func jsonProvider() []jsonCase {
+	start := time.Date(2025, 1, 8, 9, 18, 29, 0, time.UTC)
+
+	return []jsonCase{
+		{
+			name: "text bodies",
+			record: Record{
+				Start:           start,
+				End:             start.Add(1500 * time.Microsecond),
+				Method:          http.MethodPost,
+				URL:             URL,
+				Status:          http.StatusOK,
+				RequestHeader:   http.Header{headers.ContentType: {mime.JSON}},
+				RequestBody:     []byte("{\"name\":\"Boris\",\n\"age\": 20}"),
+				RequestBodySize: 27,
+				ResponseHeader:  http.Header{headers.ContentLength: {"0"}},
+			},
+			expected: `{
+				"start": "2025-01-08T09:18:29Z",
+				"end": "2025-01-08T09:18:29.0015Z",
+				"method": "POST",
+				"url": "https://localhost",
+				"request": {
+					"headers": {"Content-Type": ["application/json"]},
+					"body": "{\"name\":\"Boris\",\n\"age\": 20}",
+					"size": 27
+				},
+				"response": {
+					"headers": {"Content-Length": ["0"]}
+				},
+				"duration_ms": 1.5,
+				"status": 200
+			}`,
+		},
+		{
+			name: "binary body",
+			record: Record{
+				Start:            start,
+				End:              start,
+				Method:           http.MethodGet,
+				URL:              URL,
+				Status:           http.StatusOK,
+				ResponseBody:     []byte{0xff, 0xfe, 0x00},
+				ResponseBodySize: 3,
+			},
+			expected: `{
+				"start": "2025-01-08T09:18:29Z",
+				"end": "2025-01-08T09:18:29Z",
+				"method": "GET",
+				"url": "https://localhost",
+				"request": {},
+				"response": {"body": "//4A", "encoding": "base64", "size": 3},
+				"duration_ms": 0,
+				"status": 200
+			}`,
+		},
+		{
+			name: "truncated bodies",
+			record: Record{
+				Start:             start,
+				End:               start,
+				Method:            http.MethodPost,
+				URL:               URL,
+				Status:            http.StatusOK,
+				RequestBody:       []byte(`{"name":"B`),
+				RequestBodySize:   41,
+				RequestTruncated:  true,
+				ResponseBody:      []byte(`{"name":"B`),
+				ResponseBodySize:  -1,
+				ResponseTruncated: true,
+			},
+			expected: `{
+				"start": "2025-01-08T09:18:29Z",
+				"end": "2025-01-08T09:18:29Z",
+				"method": "POST",
+				"url": "https://localhost",
+				"request": {"body": "{\"name\":\"B", "size": 41, "truncated": true},
+				"response": {"body": "{\"name\":\"B", "size": -1, "truncated": true},
+				"duration_ms": 0,
+				"status": 200
+			}`,
+		},
+	}
+}
@@ -0,0 +1,187 @@
+package dumper
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/nafigator/http/headers"
+	"github.com/nafigator/http/masker/query"
+	"github.com/nafigator/http/mime"
+)
+
+const (
+	unexpectedRecord  = "Unexpected record"
+	unexpectedHeader  = "Unexpected header"
+	unexpectedBody    = "Unexpected body"
+	unexpectedMessage = "Unexpected message"
+	unexpectedURL     = "Unexpected URL"
+)
+
+type recorder struct {
+	records []Record
+}
+
+type messages struct {
+	list []string
+}
+
+type parseCase struct {
+	expectedHeader http.Header
+	name           string
+	dump           string
+	expectedBody   []byte
+}
+
+type urlCase struct {
+	request  *http.Request
+	name     string
+	expected string
+}
+
+func (r *recorder) FlushRecord(_ context.Context, rec Record) {
+	r.records = append(r.records, rec)
+}
+
+func (m *messages) Flush(_ context.Context, msg string) {
+	m.list = append(m.list, msg)
+}
+
+func (s *suite) TestRecord() {
+	ctrl := gomock.NewController(s.T())
+	next := NewMockHandler(ctrl)
+	rec := &recorder{}
+	start := time.Date(2025, 1, 8, 9, 18, 29, 0, time.UTC)
+	moments := []time.Time{start, start.Add(time.Second)}
+
+	d := New(nil).
+		WithRecordFlusher(rec).
+		WithMasker(query.New([]string{"secret"}))
+	d.now = func() time.Time {
+		t := moments[0]
+		moments = moments[1:]
+
+		return t
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/?secret=0123456789ABC", bytes.NewBufferString(`{"a":1}`))
+	req.Header.Set(headers.ContentType, mime.JSON)
+
+	next.EXPECT().
+		ServeHTTP(gomock.Any(), req).
+		Do(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set(headers.ContentType, mime.JSON)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"ok":true}`))
+		}).
+		Times(1)
+
+	d.MiddleWare(next).ServeHTTP(httptest.NewRecorder(), req)
+
+	s.Require().Len(rec.records, 1, unexpectedMsgCount)
+	actual := rec.records[0]
+
+	s.Equal(http.MethodPost, actual.Method, unexpectedRecord)
+	s.Equal("http://example.com/?secret=******6789ABC", actual.URL, unexpectedRecord)
+	s.Equal(http.StatusCreated, actual.Status, unexpectedRecord)
+	s.Equal(time.Second, actual.Duration(), unexpectedRecord)
+	s.Equal(mime.JSON, actual.RequestHeader.Get(headers.ContentType), unexpectedHeader)
+	s.Equal([]byte(`{"a":1}`), actual.RequestBody, unexpectedBody)
+	s.Equal(mime.JSON, actual.ResponseHeader.Get(headers.ContentType), unexpectedHeader)
+	s.Equal([]byte(`{"ok":true}`), actual.ResponseBody, unexpectedBody)
+}
+
+func (s *suite) TestRenderer() {
+	ctrl := gomock.NewController(s.T())
+	next := NewMockHandler(ctrl)
+	m := &messages{}
+
+	d := New(m).WithRenderer(func(r Record) string {
+		return r.Method + " " + r.URL
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/users", nil)
+
+	next.EXPECT().
+		ServeHTTP(gomock.Any(), req).
+		Times(1)
+
+	d.MiddleWare(next).ServeHTTP(httptest.NewRecorder(), req)
+
+	s.Equal([]string{"GET http://example.com/users"}, m.list, unexpectedMessage)
+}
+
+func (s *suite) TestRequestURL() {
+	for _, c := range urlProvider() {
+		s.Run(c.name, func() {
+			s.Equal(c.expected, requestURL(c.request), unexpectedURL)
+		})
+	}
+}
+
+func (s *suite) TestParse() {
+	for _, c := range parseProvider() {
+		s.Run(c.name, func() {
+			h, b := parse(c.dump)
+
+			s.Equal(c.expectedHeader, h, unexpectedHeader)
+			s.Equal(c.expectedBody, b, unexpectedBody)
+		})
+	}
+}
+
+func urlProvider() []urlCase {
+	absolute, _ := http.NewRequest(http.MethodGet, URL+"/users", nil)
+
+	secure := httptest.NewRequest(http.MethodGet, "/users", nil)
+	secure.TLS = &tls.ConnectionState{}
+
+	return []urlCase{
+		{
+			name:     "absolute URL",
+			request:  absolute,
+			expected: URL + "/users",
+		},
+		{
+			name:     "plain request",
+			request:  httptest.NewRequest(http.MethodGet, "/users?id=1", nil),
+			expected: "http://example.com/users?id=1",
+		},
+		{
+			name:     "TLS request",
+			request:  secure,
+			expected: "https://example.com/users",
+		},
+	}
+}
+
+func parseProvider() []parseCase {
+	return []parseCase{
+		{
+			name: "incomplete dump",
+			dump: "HTTP/1.1 200 OK\r\n",
+		},
+		{
+			name:           "without headers and body",
+			dump:           "HTTP/1.1 200 OK\r\n\r\n",
+			expectedHeader: http.Header{},
+		},
+		{
+			name:           "chunked body",
+			dump:           "HTTP/1.1 200 OK\r\nTransfer-Encoding: chunked\r\n\r\n3\r\nfoo\r\n0\r\n\r\n",
+			expectedHeader: http.Header{headers.TransferEncoding: {"chunked"}},
+			expectedBody:   []byte("foo"),
+		},
+		{
+			name:           "malformed chunked body",
+			dump:           "HTTP/1.1 200 OK\r\nTransfer-Encoding: chunked\r\n\r\nfoo",
+			expectedHeader: http.Header{headers.TransferEncoding: {"chunked"}},
+			expectedBody:   []byte("foo"),
+		},
+	}
+}